@@ -14,14 +14,26 @@ var (
 )
 
 var addCmd = &cobra.Command{
-	Use:   "add <gist-url-or-id>",
-	Short: "Install a skill from a GitHub Gist",
-	Args:  cobra.ExactArgs(1),
+	Use:   "add <gist-url-or-id>[@<sha>] | <owner>/<repo>[/<path>][@<ref>] | <git-url>[//<path>][@<ref>] | <path> | <archive>",
+	Short: "Install a skill from a GitHub Gist or repository",
+	Long: `Installs the latest revision of a gist. Append @<sha> to install and pin an exact revision;
+pinned skills are skipped by ` + "`update --all`" + `.
+
+Skills kept in a repository are installed from the directory containing SKILL.md, or from
+<path> if given. For repositories, @<ref> names the branch, tag or commit to track.
+
+For offline installs, give a local folder (./path, /path, ~/path or file://) or a .tar.gz,
+.tgz, .tar or .zip archive (local path, file:// or http(s) URL). Update re-reads the source.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		provider, snippetID := internal.DetectProvider(args[0])
-		fmt.Printf("Fetching %s snippet %s...\n", provider.Name(), snippetID)
+		provider, snippetID, revision := detectSource(args[0])
 
-		gist, err := provider.FetchSnippet(snippetID)
+		if revision != "" {
+			fmt.Printf("Fetching %s snippet %s at %s...\n", provider.Name(), snippetID, revision)
+		} else {
+			fmt.Printf("Fetching %s snippet %s...\n", provider.Name(), snippetID)
+		}
+		gist, err := fetchSnippet(provider, snippetID, revision)
 		if err != nil {
 			return err
 		}
@@ -38,49 +50,28 @@ var addCmd = &cobra.Command{
 		}
 
 		// Trust gate
-		skipPrompt := addYes || addIdgaf
-		if !skipPrompt {
-			// Own gists/snippets are implicitly trusted
-			if authUser := provider.AuthenticatedUser(); authUser != "" && strings.EqualFold(authUser, gist.Owner.Login) {
-				skipPrompt = true
-			}
-		}
-		if !skipPrompt {
-			ts, err := internal.LoadTrustStore()
-			if err != nil {
-				return err
-			}
-			if ts.IsTrusted(gist.Owner.Login) {
-				skipPrompt = true
-				fmt.Printf("Author %q is trusted.\n", gist.Owner.Login)
-			}
+		proceed, err := trustGate(provider, gist, fm, addYes || addIdgaf)
+		if err != nil {
+			return err
 		}
-
-		if !skipPrompt {
-			decision, err := internal.PromptTrust(gist, fm)
-			if err != nil {
-				return err
-			}
-			switch decision {
-			case "":
-				fmt.Println("Aborted.")
-				return nil
-			case "trust-author":
-				ts, _ := internal.LoadTrustStore()
-				ts.AddAuthor(gist.Owner.Login)
-				if err := ts.Save(); err != nil {
-					return fmt.Errorf("failed to save trust store: %w", err)
-				}
-				fmt.Printf("✓ Trusted author %q for future installs.\n", gist.Owner.Login)
-			}
+		if !proceed {
+			fmt.Println("Aborted.")
+			return nil
 		}
 
-		meta, err := internal.InstallSkill(gist, provider.Name())
+		meta, err := internal.InstallSkill(gist, provider)
 		if err != nil {
 			return err
 		}
-
-		fmt.Printf("✓ Installed skill %q (v%s)\n", meta.Name, meta.Version)
+		if revision != "" {
+			meta.Pinned = true
+			if err := internal.SaveSkillMeta(meta); err != nil {
+				return err
+			}
+			fmt.Printf("✓ Installed skill %q (v%s) pinned at %s\n", meta.Name, meta.Version, internal.ShortSHA(meta.CommitSHA))
+		} else {
+			fmt.Printf("✓ Installed skill %q (v%s)\n", meta.Name, meta.Version)
+		}
 
 		// Auto-link to detected tools
 		linked := internal.AutoLink(meta.Name)
@@ -88,6 +79,14 @@ var addCmd = &cobra.Command{
 			fmt.Printf("  → Linked to %s\n", dir)
 		}
 
+		if root, ok := internal.ProjectScope(); ok {
+			// The meta skill is the user's, not something to commit to the project
+			if len(linked) == 0 {
+				fmt.Printf("  (no .claude, .cursor, .codex or .opencode directory in %s; use `gh skill link %s --target <tool> --scope project`)\n", root, meta.Name)
+			}
+			return nil
+		}
+
 		// Lazy init: install meta skill if not present
 		ensureMetaSkill(linked)
 
@@ -95,7 +94,65 @@ var addCmd = &cobra.Command{
 	},
 }
 
+// detectSource resolves a source as given to add into its provider, snippet
+// ID and the revision to pin, if any.
+func detectSource(source string) (internal.Provider, string, string) {
+	ref, revision := internal.SplitRevision(source)
+	provider, snippetID := internal.DetectProvider(ref)
+	if _, ok := provider.(internal.RevisionResolver); ok && revision != "" {
+		// A repository ref is tracked by update rather than pinned
+		snippetID += "@" + revision
+		revision = ""
+	}
+	return provider, snippetID, revision
+}
+
+// fetchSnippet fetches a snippet at revision, or its latest revision if empty.
+func fetchSnippet(provider internal.Provider, snippetID, revision string) (*internal.Gist, error) {
+	if revision != "" {
+		return provider.FetchSnippetRevision(snippetID, revision)
+	}
+	return provider.FetchSnippet(snippetID)
+}
+
+// trustGate decides whether a fetched gist may be installed. Own gists and
+// trusted authors pass silently; everyone else goes through PromptTrust.
+func trustGate(provider internal.Provider, gist *internal.Gist, fm *internal.FrontMatter, skipPrompt bool) (bool, error) {
+	if skipPrompt {
+		return true, nil
+	}
+	// Own gists/snippets are implicitly trusted
+	if authUser := provider.AuthenticatedUser(); authUser != "" && strings.EqualFold(authUser, gist.Owner.Login) {
+		return true, nil
+	}
+	ts, err := internal.LoadTrustStore()
+	if err != nil {
+		return false, err
+	}
+	if ts.IsTrusted(gist.Owner.Login) {
+		fmt.Printf("Author %q is trusted.\n", gist.Owner.Login)
+		return true, nil
+	}
+
+	decision, err := internal.PromptTrust(gist, fm)
+	if err != nil {
+		return false, err
+	}
+	switch decision {
+	case "":
+		return false, nil
+	case "trust-author":
+		ts.AddAuthor(gist.Owner.Login)
+		if err := ts.Save(); err != nil {
+			return false, fmt.Errorf("failed to save trust store: %w", err)
+		}
+		fmt.Printf("✓ Trusted author %q for future installs.\n", gist.Owner.Login)
+	}
+	return true, nil
+}
+
 func init() {
 	addCmd.Flags().BoolVarP(&addYes, "yes", "y", false, "Skip trust prompt")
 	addCmd.Flags().BoolVar(&addIdgaf, "idgaf", false, "Skip trust prompt (alias)")
+	addScopeFlag(addCmd)
 }
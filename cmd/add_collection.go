@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var addCollectionYes bool
+
+var addCollectionCmd = &cobra.Command{
+	Use:   "add-collection <url-or-id>",
+	Short: "Install every skill listed in a collection",
+	Long: `Fetches a collection — a gist (or any other source add accepts) containing a gistskills.json
+index — and installs every skill it lists after a single trust prompt. Skills you
+already installed on their own are updated but stay yours: the collection never
+removes them.
+
+Use ` + "`update --collection <name>`" + ` to pick up changed, added and removed members later.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := internal.LoadCollectionStore()
+		if err != nil {
+			return err
+		}
+		c, revision, err := fetchCollection(args[0])
+		if err != nil {
+			return err
+		}
+		if store.Find(c.Name) != nil {
+			return fmt.Errorf("collection %q is already installed; use `gh skill update --collection %q`", c.Name, c.Name)
+		}
+
+		members, proceed, err := installCollectionMembers(c, c.Skills, addCollectionYes)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			fmt.Println("Aborted.")
+			return nil
+		}
+
+		store.Put(internal.InstalledCollection{
+			Name:     c.Name,
+			Source:   args[0],
+			Revision: revision,
+			Members:  members,
+		})
+		if err := store.Save(); err != nil {
+			return fmt.Errorf("failed to save collections: %w", err)
+		}
+		fmt.Printf("✓ Installed collection %q (%d skill(s))\n", c.Name, len(members))
+		return nil
+	},
+}
+
+// fetchCollection fetches and parses the index of the collection at source,
+// returning it with the revision it was read at.
+func fetchCollection(source string) (*internal.Collection, string, error) {
+	provider, snippetID, revision := detectSource(source)
+	fmt.Printf("Fetching %s collection %s...\n", provider.Name(), snippetID)
+	gist, err := fetchSnippet(provider, snippetID, revision)
+	if err != nil {
+		return nil, "", err
+	}
+	c, err := internal.ParseCollection(gist)
+	if err != nil {
+		return nil, "", err
+	}
+	return c, gist.Revision(), nil
+}
+
+// installCollectionMembers fetches every source, shows one trust prompt for
+// all of them unless each author is already trusted, and installs them as
+// members of c. Members that fail to install are reported and left out of
+// the result. It returns false if the user declined the prompt.
+func installCollectionMembers(c *internal.Collection, sources []string, skipPrompt bool) ([]internal.CollectionMember, bool, error) {
+	type member struct {
+		source   string
+		provider internal.Provider
+		gist     *internal.Gist
+		pinned   bool
+	}
+	var fetched []member
+	var gists []*internal.Gist
+	for _, source := range sources {
+		provider, snippetID, revision := detectSource(source)
+		gist, err := fetchSnippet(provider, snippetID, revision)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to fetch %s: %w", source, err)
+		}
+		if _, _, ok := internal.FindSkillFile(gist.Files); !ok {
+			return nil, false, fmt.Errorf("%s does not contain a *.skill.md file", source)
+		}
+		fetched = append(fetched, member{source, provider, gist, revision != ""})
+		gists = append(gists, gist)
+	}
+
+	ts, err := internal.LoadTrustStore()
+	if err != nil {
+		return nil, false, err
+	}
+	var untrusted []string
+	for _, m := range fetched {
+		login := m.gist.Owner.Login
+		if authUser := m.provider.AuthenticatedUser(); authUser != "" && strings.EqualFold(authUser, login) {
+			continue
+		}
+		if !ts.IsTrusted(login) && !slices.Contains(untrusted, login) {
+			untrusted = append(untrusted, login)
+		}
+	}
+	if len(untrusted) > 0 && !skipPrompt {
+		decision, err := internal.PromptCollectionTrust(c, gists, ts)
+		if err != nil {
+			return nil, false, err
+		}
+		switch decision {
+		case "":
+			return nil, false, nil
+		case "trust-author":
+			for _, login := range untrusted {
+				ts.AddAuthor(login)
+			}
+			if err := ts.Save(); err != nil {
+				return nil, false, fmt.Errorf("failed to save trust store: %w", err)
+			}
+			fmt.Printf("✓ Trusted %s for future installs.\n", strings.Join(untrusted, ", "))
+		}
+	}
+
+	var members []internal.CollectionMember
+	var linked []string
+	for _, m := range fetched {
+		// A skill the user installed on its own stays theirs: the collection
+		// updates it but never removes it
+		standalone := false
+		if name, err := internal.SkillName(m.gist); err == nil {
+			if existing, err := internal.GetSkill(name); err == nil && len(existing.Collections) == 0 {
+				standalone = true
+			}
+		}
+		meta, err := internal.InstallSkill(m.gist, m.provider)
+		if err == nil {
+			if !standalone {
+				meta.Collections.Add(c.Name)
+			}
+			meta.Pinned = m.pinned
+			err = internal.SaveSkillMeta(meta)
+		}
+		if err != nil {
+			fmt.Printf("✗ Failed to install %s: %v\n", m.source, err)
+			continue
+		}
+		members = append(members, internal.CollectionMember{Source: m.source, Skill: meta.Name})
+		fmt.Printf("✓ Installed skill %q (v%s)\n", meta.Name, meta.Version)
+		if standalone {
+			fmt.Printf("  (already installed on its own; %q will not remove it)\n", c.Name)
+		}
+		linked = internal.AutoLink(meta.Name)
+		for _, dir := range linked {
+			fmt.Printf("  → Linked to %s\n", dir)
+		}
+	}
+	ensureMetaSkill(linked)
+	return members, true, nil
+}
+
+func init() {
+	addCollectionCmd.Flags().BoolVarP(&addCollectionYes, "yes", "y", false, "Skip trust prompt")
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	collectionDescription string
+	collectionPublic      bool
+	collectionProvider    string
+	collectionHost        string
+)
+
+var collectionCmd = &cobra.Command{
+	Use:   "collection",
+	Short: "Build and publish collections of skills",
+	Long: `Maintains gistskills.json collection indexes under ~/.gistskills/authored-collections.json
+and publishes them as gists that others install with ` + "`gh skill add-collection`" + `.`,
+}
+
+var collectionCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Start a new, empty collection",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, err := internal.LoadAuthoredCollectionStore()
+		if err != nil {
+			return err
+		}
+		if store.Find(args[0]) != nil {
+			return fmt.Errorf("collection %q already exists", args[0])
+		}
+		store.Collections = append(store.Collections, internal.AuthoredCollection{
+			Collection: internal.Collection{Name: args[0], Description: collectionDescription},
+		})
+		if err := store.Save(); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Created collection %q\n", args[0])
+		fmt.Printf("  Add skills with: gh skill collection add %q <skill>...\n", args[0])
+		return nil
+	},
+}
+
+var collectionAddCmd = &cobra.Command{
+	Use:   "add <collection> <skill>...",
+	Short: "Add installed skills to a collection",
+	Long:  "Adds each installed skill's source to the collection. Pinned skills are added at their pinned revision.\nSkills installed from local files cannot be added; publish them first.",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, c, err := loadAuthoredCollection(args[0])
+		if err != nil {
+			return err
+		}
+		for _, name := range args[1:] {
+			meta, err := internal.GetSkill(name)
+			if err != nil {
+				return err
+			}
+			source, err := skillSource(meta)
+			if err != nil {
+				return err
+			}
+			if c.Add(source) {
+				fmt.Printf("✓ Added %q to %q\n", meta.Name, c.Name)
+			} else {
+				fmt.Printf("- %q is already in %q\n", meta.Name, c.Name)
+			}
+		}
+		return store.Save()
+	},
+}
+
+var collectionRemoveCmd = &cobra.Command{
+	Use:   "remove <collection> <skill-or-source>...",
+	Short: "Remove skills from a collection",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, c, err := loadAuthoredCollection(args[0])
+		if err != nil {
+			return err
+		}
+		for _, arg := range args[1:] {
+			target := arg
+			if meta, err := internal.GetSkill(arg); err == nil {
+				if source, err := skillSource(meta); err == nil {
+					target = source
+				}
+			}
+			base, _ := internal.SplitRevision(target)
+			removed := c.Remove(func(source string) bool {
+				s, _ := internal.SplitRevision(source)
+				return source == arg || s == base
+			})
+			if removed == 0 {
+				return fmt.Errorf("%q is not in collection %q", arg, c.Name)
+			}
+			fmt.Printf("✓ Removed %q from %q\n", arg, c.Name)
+		}
+		return store.Save()
+	},
+}
+
+var collectionPublishCmd = &cobra.Command{
+	Use:   "publish <collection>",
+	Short: "Publish a collection's gistskills.json index",
+	Long: `Publishes the collection as a gist (secret by default) the first time, and updates that same
+gist on later publishes so the install URL stays the same.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store, c, err := loadAuthoredCollection(args[0])
+		if err != nil {
+			return err
+		}
+		if len(c.Skills) == 0 {
+			return fmt.Errorf("collection %q is empty", c.Name)
+		}
+		for _, source := range c.Skills {
+			ref, _ := internal.SplitRevision(source)
+			if p, id := internal.DetectProvider(ref); p.Name() == "local" || (p.Name() == "archive" && filepath.IsAbs(id)) {
+				return fmt.Errorf("%s is a local path others cannot install from; remove it from %q", source, c.Name)
+			}
+		}
+		description := c.Description
+		if description == "" {
+			description = c.Name
+		}
+		description = "[gh-skill] " + description
+		files := map[string]string{internal.CollectionFile: c.Index()}
+
+		var gist *internal.Gist
+		if c.GistID != "" {
+			provider := internal.NewProvider(c.Provider, c.Host)
+			fmt.Printf("Updating %s snippet %s...\n", provider.Name(), c.GistID)
+			if gist, err = provider.UpdateSnippet(c.GistID, description, files); err != nil {
+				return err
+			}
+		} else {
+			provider := internal.NewProvider(collectionProvider, collectionHost)
+			visibility := "secret"
+			if collectionPublic {
+				visibility = "public"
+			}
+			fmt.Printf("Publishing collection %q as a %s %s snippet...\n", c.Name, visibility, provider.Name())
+			if gist, err = provider.CreateSnippet(description, files, collectionPublic); err != nil {
+				return err
+			}
+			c.Provider, c.Host, c.GistID = provider.Name(), provider.Host(), gist.ID
+		}
+		if gist.HTMLURL != "" {
+			c.URL = gist.HTMLURL
+		}
+		if err := store.Save(); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Published: %s\n", c.URL)
+		fmt.Printf("  Install with: gh skill add-collection %s\n", c.URL)
+		return nil
+	},
+}
+
+func loadAuthoredCollection(name string) (*internal.AuthoredCollectionStore, *internal.AuthoredCollection, error) {
+	store, err := internal.LoadAuthoredCollectionStore()
+	if err != nil {
+		return nil, nil, err
+	}
+	c := store.Find(name)
+	if c == nil {
+		return nil, nil, fmt.Errorf("collection %q not found (create it with `gh skill collection create`)", name)
+	}
+	return store, c, nil
+}
+
+// skillSource returns the source a collection lists for an installed skill,
+// at the pinned revision if the skill is pinned. Skills installed from local
+// files have no source others can install from.
+func skillSource(meta *internal.SkillMeta) (string, error) {
+	source, ok := internal.SnippetSource(meta.EffectiveProvider(), meta.Host, meta.GistID)
+	if !ok {
+		return "", fmt.Errorf("%q was installed from %s, which others cannot install from; publish it first", meta.Name, meta.GistID)
+	}
+	if meta.Pinned && meta.CommitSHA != "" {
+		source += "@" + meta.CommitSHA
+	}
+	return source, nil
+}
+
+func init() {
+	collectionCreateCmd.Flags().StringVar(&collectionDescription, "description", "", "Collection description")
+	collectionPublishCmd.Flags().BoolVar(&collectionPublic, "public", false, "Create a public gist/snippet on first publish")
+	collectionPublishCmd.Flags().StringVar(&collectionProvider, "provider", "github", "Provider to publish to on first publish")
+	collectionPublishCmd.Flags().StringVar(&collectionHost, "host", "", "Self-hosted GitLab, GitHub Enterprise or Gitea/Forgejo host")
+
+	collectionCmd.AddCommand(collectionCreateCmd)
+	collectionCmd.AddCommand(collectionAddCmd)
+	collectionCmd.AddCommand(collectionRemoveCmd)
+	collectionCmd.AddCommand(collectionPublishCmd)
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var exportOutput string
+
+var exportCmd = &cobra.Command{
+	Use:   "export [name...]",
+	Short: "Pack installed skills into a portable bundle",
+	Long: `Writes the named skills (default: all installed skills) to a .tar.gz bundle, together with
+their metadata, the tools they are linked to and the trust store entries for their authors.
+Restore the bundle on another machine with ` + "`gh skill import`" + `.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		names := args
+		if len(names) == 0 {
+			skills, err := internal.ListSkills()
+			if err != nil {
+				return err
+			}
+			for _, s := range skills {
+				names = append(names, s.Name)
+			}
+		}
+		if len(names) == 0 {
+			fmt.Println("No skills installed.")
+			return nil
+		}
+
+		f, err := os.Create(exportOutput)
+		if err != nil {
+			return err
+		}
+		manifest, err := internal.ExportBundle(f, names)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(exportOutput)
+			return err
+		}
+		fmt.Printf("✓ Exported %d skill(s) to %s\n", len(manifest.Skills), exportOutput)
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "gh-skills.tar.gz", "Bundle file to write")
+}
@@ -4,15 +4,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 
 	"github.com/nicholasspencer/gh-skill/internal"
 	"github.com/spf13/cobra"
 )
 
 var (
-	forkPublic   bool
-	forkProvider string
+	forkPublic       bool
+	forkProvider     string
+	forkHost         string
+	forkGitignore    bool
+	forkDryRun       bool
+	forkAllowSecrets bool
 )
 
 var forkCmd = &cobra.Command{
@@ -58,23 +61,19 @@ Search order:
 		description = "[gh-skill] " + description
 
 		// Collect all files
-		files := make(map[string]string)
-		filepath.Walk(skillDir, func(p string, fi os.FileInfo, err error) error {
-			if err != nil || fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
-				return nil
-			}
-			rel, _ := filepath.Rel(skillDir, p)
-			content, err := os.ReadFile(p)
-			if err != nil {
-				return nil
-			}
-			gistName := internal.FlattenFilename(rel)
-			if gistName == "SKILL.md" {
-				gistName = internal.SkillFileName(skillName)
-			}
-			files[gistName] = string(content)
+		collected, err := internal.CollectPublishFiles(skillDir, skillName, forkGitignore)
+		if err != nil {
+			return err
+		}
+		printPublishFiles(collected)
+		if err := checkSecrets(collected, forkAllowSecrets); err != nil {
+			return err
+		}
+		if forkDryRun {
+			fmt.Println("Dry run: nothing was published.")
 			return nil
-		})
+		}
+		files := internal.PublishPayload(collected)
 
 		visibility := "secret"
 		if forkPublic {
@@ -82,7 +81,7 @@ Search order:
 		}
 		fmt.Printf("Publishing %d files as a %s gist...\n", len(files), visibility)
 
-		provider := internal.ProviderByName(forkProvider)
+		provider := internal.NewProvider(forkProvider, forkHost)
 		gist, err := provider.CreateSnippet(description, files, forkPublic)
 		if err != nil {
 			return err
@@ -140,5 +139,9 @@ func hasSkillMD(dir string) bool {
 
 func init() {
 	forkCmd.Flags().BoolVar(&forkPublic, "public", false, "Create a public gist")
-	forkCmd.Flags().StringVar(&forkProvider, "provider", "github", "Target provider (github, gitlab)")
+	forkCmd.Flags().StringVar(&forkProvider, "provider", "github", "Target provider (github, gitlab, gitea)")
+	forkCmd.Flags().StringVar(&forkHost, "host", "", "Self-hosted GitLab, GitHub Enterprise or Gitea/Forgejo host")
+	forkCmd.Flags().BoolVar(&forkGitignore, "gitignore", false, "Also leave out files matched by the skill's .gitignore")
+	forkCmd.Flags().BoolVar(&forkDryRun, "dry-run", false, "List the files that would be published without publishing")
+	forkCmd.Flags().BoolVar(&forkAllowSecrets, "allow-secrets", false, "Publish even if likely secrets are found")
 }
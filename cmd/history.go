@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <name>",
+	Short: "List previously installed revisions of a skill",
+	Long:  "Lists revisions kept under ~/.gistskills/.history/<name>/. Set history_retention in ~/.gistskills/config.json to change how many are kept.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		current, err := internal.GetSkill(args[0])
+		if err != nil {
+			return err
+		}
+		revs, err := internal.SkillHistory(current.Name)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "\tCOMMIT\tVERSION\tINSTALLED")
+		fmt.Fprintf(w, "*\t%s\t%s\t%s\n", internal.ShortSHA(current.CommitSHA), orDash(current.Version), current.UpdatedAt)
+		for _, r := range revs {
+			if r.Meta.CommitSHA == current.CommitSHA {
+				continue
+			}
+			fmt.Fprintf(w, "\t%s\t%s\t%s\n", internal.ShortSHA(r.Meta.CommitSHA), orDash(r.Meta.Version), r.Meta.UpdatedAt)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if len(revs) == 0 {
+			fmt.Println("\nNo previous revisions saved.")
+		}
+		return nil
+	},
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
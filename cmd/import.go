@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <bundle>",
+	Short: "Restore skills from a bundle created by export",
+	Long: `Installs every skill in a bundle created by ` + "`gh skill export`" + ` with its original metadata,
+adds the bundled authors to the trust store and recreates the skills' links. No network
+access is needed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		manifest, linkErrs, err := internal.ImportBundle(f)
+		if err != nil {
+			return err
+		}
+		for _, s := range manifest.Skills {
+			fmt.Printf("✓ Imported %q\n", s.Name)
+		}
+		for _, a := range manifest.TrustedAuthors {
+			fmt.Printf("✓ Trusted author %q\n", a.Username)
+		}
+		for _, err := range linkErrs {
+			fmt.Printf("⚠️  Could not link %v\n", err)
+		}
+		return nil
+	},
+}
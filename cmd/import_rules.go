@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importRulesOutput string
+	importRulesDryRun bool
+)
+
+var importRulesCmd = &cobra.Command{
+	Use:   "import-rules <path>...",
+	Short: "Convert other tools' rule files into skill folders",
+	Long: `Reads rule files and writes a skill folder for each rule, with generated front matter,
+ready for ` + "`gh skill publish`" + `. A path may be a file or a directory to search.
+
+  Cursor rules (*.mdc, .cursorrules)    one skill per file; globs become a note in the body
+  Copilot (*.instructions.md)           one skill per file
+  CLAUDE.md, AGENTS.md,
+  copilot-instructions.md               one skill per ## section, or one for the whole file
+
+The description is taken from the rule's front matter, or else its first paragraph.
+Existing non-empty folders are never overwritten. Review each SKILL.md (gh skill lint
+helps) before publishing.`,
+	Example: `  gh skill import-rules .cursor/rules -o skills
+  gh skill import-rules CLAUDE.md --dry-run`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var rules []internal.ImportedRule
+		for _, arg := range args {
+			files, err := internal.FindRuleFiles(arg)
+			if err != nil {
+				return err
+			}
+			for _, f := range files {
+				parsed, err := internal.ParseRuleFile(f)
+				if err != nil {
+					return err
+				}
+				rules = append(rules, parsed...)
+			}
+		}
+		if len(rules) == 0 {
+			fmt.Println("No rules found.")
+			return nil
+		}
+
+		// Rules from different files can share a name; number the later ones
+		used := make(map[string]int)
+		created := 0
+		for _, r := range rules {
+			used[r.Name]++
+			if n := used[r.Name]; n > 1 {
+				r.Name += "-" + strconv.Itoa(n)
+			}
+			dir := filepath.Join(importRulesOutput, r.Name)
+			if importRulesDryRun {
+				fmt.Printf("%s ← %s\n", dir, r.Source)
+				continue
+			}
+			if err := internal.WriteSkillFolder(dir, internal.RuleSkillFiles(r)); err != nil {
+				fmt.Printf("✗ Skipped %s: %v\n", r.Source, err)
+				continue
+			}
+			fmt.Printf("✓ Created %s ← %s\n", dir, r.Source)
+			created++
+		}
+		if importRulesDryRun {
+			fmt.Printf("(dry run: %d skill(s) would be created)\n", len(rules))
+			return nil
+		}
+		if created > 0 {
+			fmt.Printf("\n%d skill(s) created. Review them with `gh skill lint`, then `gh skill publish <dir>`.\n", created)
+		}
+		return nil
+	},
+}
+
+func init() {
+	importRulesCmd.Flags().StringVarP(&importRulesOutput, "output", "o", ".", "Directory to create the skill folders in")
+	importRulesCmd.Flags().BoolVar(&importRulesDryRun, "dry-run", false, "List the skills that would be created without writing them")
+}
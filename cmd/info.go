@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/nicholasspencer/gh-skill/internal"
 	"github.com/spf13/cobra"
@@ -25,8 +26,24 @@ var infoCmd = &cobra.Command{
 		fmt.Printf("Author:      %s\n", meta.Author)
 		fmt.Printf("Gist:        %s\n", meta.GistURL)
 		fmt.Printf("Provider:    %s\n", meta.EffectiveProvider())
+		if meta.Host != "" {
+			fmt.Printf("Host:        %s\n", meta.Host)
+		}
+		if meta.Repo != "" {
+			fmt.Printf("Repository:  %s\n", meta.Repo)
+		}
+		if meta.Path != "" {
+			fmt.Printf("Path:        %s\n", meta.Path)
+		}
 		fmt.Printf("Gist ID:     %s\n", meta.GistID)
-		fmt.Printf("Commit:      %s\n", meta.CommitSHA)
+		if len(meta.Collections) > 0 {
+			fmt.Printf("Collection:  %s\n", strings.Join(meta.Collections, ", "))
+		}
+		if meta.Pinned {
+			fmt.Printf("Commit:      %s (pinned)\n", meta.CommitSHA)
+		} else {
+			fmt.Printf("Commit:      %s\n", meta.CommitSHA)
+		}
 		fmt.Printf("Installed:   %s\n", meta.InstalledAt)
 		fmt.Printf("Updated:     %s\n", meta.UpdatedAt)
 
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,27 +20,28 @@ var installCmd = &cobra.Command{
 	Long:  "Downloads gist files directly without linking or managing. Use -o to specify an output directory. Prompts before overwriting existing files.",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		gistID := internal.ParseGistID(args[0])
+		provider, snippetID, revision := detectSource(args[0])
 
-		fmt.Printf("Fetching gist %s...\n", gistID)
-		gist, err := internal.FetchGist(gistID)
+		if revision != "" {
+			fmt.Printf("Fetching %s snippet %s at %s...\n", provider.Name(), snippetID, revision)
+		} else {
+			fmt.Printf("Fetching %s snippet %s...\n", provider.Name(), snippetID)
+		}
+		gist, err := fetchSnippet(provider, snippetID, revision)
 		if err != nil {
 			return err
 		}
 
-		// Find skill file to determine name
-		skillFileName, skillFile, ok := internal.FindSkillFile(gist.Files)
-		if !ok {
-			return fmt.Errorf("gist does not contain a *.skill.md file")
+		// Name the folder the way add names the installed skill
+		name, err := internal.SkillName(gist)
+		if err != nil {
+			return err
 		}
-
-		fm, _ := internal.ParseFrontMatter(skillFile.Content)
-		name := fm.Name
-		if name == "" {
-			name = internal.SkillNameFromFile(skillFileName)
+		if err := internal.ValidateSkillName(name); err != nil {
+			return err
 		}
-		if name == "" {
-			name = gist.ID
+		if errs := internal.CheckGistFiles(gist); len(errs) > 0 {
+			return errors.Join(errs...)
 		}
 
 		// Determine output directory
@@ -77,18 +79,18 @@ var installCmd = &cobra.Command{
 			return fmt.Errorf("failed to create directory %s: %w", destDir, err)
 		}
 
-		// Write all files, expanding paths and renaming skill file
+		// Write all files, expanding paths, renaming the skill file and
+		// decoding binary files
 		fileCount := 0
-		for filename, file := range gist.Files {
-			expanded := internal.ExpandFilename(filename)
-			if internal.IsSkillFile(expanded) {
-				expanded = "SKILL.md"
+		for expanded, content := range internal.GistInstallFiles(gist) {
+			destPath, err := internal.SafeJoin(destDir, expanded)
+			if err != nil {
+				return err
 			}
-			destPath := filepath.Join(destDir, expanded)
 			if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
 				return fmt.Errorf("failed to create directory for %s: %w", expanded, err)
 			}
-			if err := os.WriteFile(destPath, []byte(file.Content), 0644); err != nil {
+			if err := os.WriteFile(destPath, []byte(content), 0644); err != nil {
 				return fmt.Errorf("failed to write %s: %w", expanded, err)
 			}
 			fileCount++
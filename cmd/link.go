@@ -12,11 +12,34 @@ var linkTarget string
 var linkCmd = &cobra.Command{
 	Use:   "link <name> --target <tool>",
 	Short: "Link a skill to a specific tool's skill directory",
-	Args:  cobra.ExactArgs(1),
+	Long: `Links a skill into a tool's skill directory.
+
+Tools that do not read skill folders get the skill rendered into their own format
+instead, in the current project:
+
+  cursor-rules          .cursor/rules/<name>.mdc
+  copilot-instructions  a section of .github/copilot-instructions.md
+  agents-md             a section of AGENTS.md
+
+Rendered files are regenerated when the skill is updated and removed with it.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 		if linkTarget == "" {
-			return fmt.Errorf("--target is required (claude-code, openclaw, openclaw/<agent>, copilot, cursor, codex, opencode)")
+			return fmt.Errorf("--target is required (claude-code, openclaw, openclaw/<agent>, copilot, cursor, codex, opencode, cursor-rules, copilot-instructions, agents-md)")
+		}
+
+		if format, ok := internal.NativeFormatByName(linkTarget); ok {
+			root := "."
+			if project, ok := internal.ProjectScope(); ok {
+				root = project
+			}
+			path, err := internal.RenderNativeSkill(name, format, root)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✓ Rendered %q → %s\n", name, path)
+			return nil
 		}
 
 		dir, err := internal.ToolDirByName(linkTarget)
@@ -34,5 +57,6 @@ var linkCmd = &cobra.Command{
 }
 
 func init() {
-	linkCmd.Flags().StringVar(&linkTarget, "target", "", "Target tool (claude-code, openclaw[/<agent>], copilot, cursor, codex, opencode)")
+	linkCmd.Flags().StringVar(&linkTarget, "target", "", "Target tool (claude-code, openclaw[/<agent>], copilot, cursor, codex, opencode, cursor-rules, copilot-instructions, agents-md)")
+	addScopeFlag(linkCmd)
 }
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lintJSON      bool
+	lintStrict    bool
+	lintGitignore bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint <path-or-name>...",
+	Short: "Check skill folders against the skill spec",
+	Long: `Checks each skill folder (or installed skill, by name) for:
+
+  - front matter: present, valid YAML, no unknown fields
+  - name: required, lowercase letters, digits and hyphens, matching the folder name
+  - description: required and at most 1024 characters
+  - version: a semantic version, if set
+  - tools: names of tools gh skill knows
+  - relative Markdown links to files that are not part of the skill
+  - files over the gist size limits, and files that collide once flattened with --
+
+Exits nonzero if any errors are found (or any warnings, with --strict).
+Use --json for a machine-readable report.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true // a failed lint is a result, not a usage error
+		var reports []*internal.LintReport
+		for _, arg := range args {
+			report, err := internal.LintSkill(lintTarget(arg), lintGitignore)
+			if err != nil {
+				return err
+			}
+			reports = append(reports, report)
+		}
+
+		failed := 0
+		for _, r := range reports {
+			if r.Errors() > 0 || (lintStrict && len(r.Issues) > 0) {
+				failed++
+			}
+		}
+		if lintJSON {
+			for _, r := range reports {
+				if r.Issues == nil {
+					r.Issues = []internal.LintIssue{}
+				}
+			}
+			data, _ := json.MarshalIndent(reports, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			for _, r := range reports {
+				printLintReport(r)
+			}
+		}
+		if failed > 0 {
+			return fmt.Errorf("%d of %d skill(s) failed lint", failed, len(reports))
+		}
+		return nil
+	},
+}
+
+// lintTarget resolves a lint argument: an existing directory is linted as
+// is, anything else is taken as the name of an installed skill.
+func lintTarget(arg string) string {
+	if info, err := os.Stat(arg); err == nil && info.IsDir() {
+		return arg
+	}
+	if err := internal.ValidateSkillName(arg); err == nil {
+		if dir := filepath.Join(internal.SkillsBasePath(), arg); hasSkillMD(dir) {
+			return dir
+		}
+	}
+	return arg
+}
+
+// printLintReport prints a report's issues, or a check mark if it has none.
+func printLintReport(r *internal.LintReport) {
+	if len(r.Issues) == 0 {
+		fmt.Printf("✓ %s: no problems found\n", r.Path)
+		return
+	}
+	fmt.Printf("%s:\n", r.Path)
+	for _, i := range r.Issues {
+		fmt.Printf("  %s\n", i)
+	}
+	fmt.Printf("  %d error(s), %d warning(s)\n", r.Errors(), len(r.Issues)-r.Errors())
+}
+
+func init() {
+	lintCmd.Flags().BoolVar(&lintJSON, "json", false, "Print the reports as JSON")
+	lintCmd.Flags().BoolVar(&lintStrict, "strict", false, "Fail on warnings too")
+	lintCmd.Flags().BoolVar(&lintGitignore, "gitignore", false, "Also leave out files matched by the folder's .gitignore")
+}
@@ -4,14 +4,20 @@ import (
 	"fmt"
 	"text/tabwriter"
 	"os"
+	"strings"
 
 	"github.com/nicholasspencer/gh-skill/internal"
 	"github.com/spf13/cobra"
 )
 
+var listOutdated bool
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List installed skills",
+	Long: `Lists installed skills. With --outdated, fetches the latest revision of each skill and
+lists those with a newer version: semver front matter versions are compared when both
+sides have one, commit SHAs otherwise.`,
 	Aliases: []string{"ls"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		skills, err := internal.ListSkills()
@@ -22,6 +28,9 @@ var listCmd = &cobra.Command{
 			fmt.Println("No skills installed. Use `gh skill add <gist>` to install one.")
 			return nil
 		}
+		if listOutdated {
+			return listOutdatedSkills(skills)
+		}
 
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 		fmt.Fprintln(w, "NAME\tVERSION\tGIST\tINSTALLED")
@@ -30,8 +39,67 @@ var listCmd = &cobra.Command{
 			if version == "" {
 				version = "-"
 			}
+			if s.Pinned {
+				version += " (pinned)"
+			}
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, version, s.GistID, s.InstalledAt[:10])
 		}
 		return w.Flush()
 	},
 }
+
+// listOutdatedSkills prints the skills whose latest revision is newer than
+// the installed one. Skills that could not be checked are listed with the
+// error and fail the command.
+func listOutdatedSkills(skills []internal.SkillMeta) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tINSTALLED\tLATEST\tNOTE")
+	outdated, failed := 0, 0
+	for _, s := range skills {
+		installed := displayVersion(s.Version)
+		provider := internal.NewProvider(s.EffectiveProvider(), s.Host)
+		gist, err := provider.FetchSnippet(s.GistID)
+		if err != nil {
+			fmt.Fprintf(w, "%s\t%s\t?\t%v\n", s.Name, installed, err)
+			failed++
+			continue
+		}
+		latest := ""
+		if _, skillFile, ok := internal.FindSkillFile(gist.Files); ok {
+			if fm, err := internal.ParseFrontMatter(skillFile.Content); err == nil {
+				latest = fm.Version
+			}
+		}
+		cmp, ok := internal.CompareVersions(latest, s.Version)
+		if ok && cmp <= 0 {
+			continue
+		}
+		if !ok && (gist.Revision() == "" || gist.Revision() == s.CommitSHA) {
+			continue
+		}
+		note := ""
+		if !ok {
+			note = "revision " + internal.ShortSHA(s.CommitSHA) + " → " + internal.ShortSHA(gist.Revision())
+		}
+		if s.Pinned {
+			note = strings.TrimSpace(note + " (pinned)")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Name, installed, displayVersion(latest), note)
+		outdated++
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("could not check %d skill(s) for updates", failed)
+	}
+	if outdated == 0 {
+		fmt.Println("All skills are up to date.")
+	}
+	return nil
+}
+
+func init() {
+	listCmd.Flags().BoolVar(&listOutdated, "outdated", false, "Only list skills with a newer version available")
+	addScopeFlag(listCmd)
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+//go:embed all:new_templates
+var newTemplateFS embed.FS
+
+var (
+	newTemplate    string
+	newDescription string
+	newOutput      string
+)
+
+var newCmd = &cobra.Command{
+	Use:   "new <name>",
+	Short: "Create a skill folder from a template",
+	Long: `Creates ./<name> with a SKILL.md, a .skillignore and the layout of the chosen template.
+
+Built-in templates:
+  basic      SKILL.md only
+  script     SKILL.md plus a scripts/ folder with a script to run
+  reference  SKILL.md plus a references/ folder loaded on demand
+
+--template also takes the name of a folder in ~/.gistskills/templates, or a gist
+(ID, URL or any source add accepts) to start from. Files ending in .tmpl are
+rendered with Go's text/template and lose the suffix; {{.Name}}, {{.Title}} and
+{{.Description}} are available. The skill name in SKILL.md is always set.`,
+	Example: `  gh skill new pdf-tools
+  gh skill new deploy-helper --template script --description "Deploys the app to staging"
+  gh skill new my-skill --template aa5a315d61ae9438b18d`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if err := internal.CheckSkillName(name); err != nil {
+			return err
+		}
+		files, err := loadSkillTemplate(newTemplate)
+		if err != nil {
+			return err
+		}
+		description := newDescription
+		if description == "" {
+			description = "TODO: say what this skill does and when to use it."
+		}
+		files, err = internal.RenderSkillTemplate(files, internal.NewSkillTemplateData(name, description))
+		if err != nil {
+			return fmt.Errorf("template %q: %w", newTemplate, err)
+		}
+
+		dir := filepath.Join(newOutput, name)
+		if err := internal.WriteSkillFolder(dir, files); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Created %s from the %s template\n", dir, newTemplate)
+		var rels []string
+		for rel := range files {
+			rels = append(rels, rel)
+		}
+		sort.Strings(rels)
+		for _, rel := range rels {
+			fmt.Printf("  %s\n", rel)
+		}
+		fmt.Printf("\nEdit %s, then check it with `gh skill lint %s` and share it with `gh skill publish %s`.\n",
+			filepath.Join(dir, "SKILL.md"), dir, dir)
+		return nil
+	},
+}
+
+// loadSkillTemplate returns the files of the named template: a user
+// template, then a built-in one, then a gist or other source.
+func loadSkillTemplate(name string) (map[string]string, error) {
+	files, ok, err := internal.LoadUserTemplate(name)
+	if err != nil || ok {
+		return files, err
+	}
+	if files, ok := builtinTemplate(name); ok {
+		return files, nil
+	}
+	if !looksLikeSource(name) {
+		return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(templateNames(), ", "))
+	}
+
+	provider, snippetID, revision := detectSource(name)
+	fmt.Printf("Fetching template %s...\n", name)
+	gist, err := fetchSnippet(provider, snippetID, revision)
+	if err != nil {
+		return nil, err
+	}
+	if errs := internal.CheckGistFiles(gist); len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return internal.GistInstallFiles(gist), nil
+}
+
+// builtinTemplate reads an embedded template, keyed by slash-separated path.
+func builtinTemplate(name string) (map[string]string, bool) {
+	root := path.Join("new_templates", name)
+	if info, err := fs.Stat(newTemplateFS, root); err != nil || !info.IsDir() {
+		return nil, false
+	}
+	files := make(map[string]string)
+	err := fs.WalkDir(newTemplateFS, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := newTemplateFS.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[strings.TrimPrefix(p, root+"/")] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, false
+	}
+	return files, true
+}
+
+// templateNames lists the built-in and user template names.
+func templateNames() []string {
+	var names []string
+	entries, _ := newTemplateFS.ReadDir("new_templates")
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return append(names, internal.UserTemplates()...)
+}
+
+// looksLikeSource reports whether a template argument names a gist or other
+// source rather than a template: a URL, a prefixed reference or a gist ID.
+func looksLikeSource(s string) bool {
+	if strings.Contains(s, ":") || strings.Contains(s, "/") {
+		return true
+	}
+	ref, _ := internal.SplitRevision(s)
+	if len(ref) < 20 {
+		return false
+	}
+	for _, r := range ref {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	newCmd.Flags().StringVarP(&newTemplate, "template", "t", "basic", "Template: basic, script, reference, a user template name or a gist")
+	newCmd.Flags().StringVarP(&newDescription, "description", "d", "", "Description for the SKILL.md front matter")
+	newCmd.Flags().StringVarP(&newOutput, "output", "o", ".", "Directory to create the skill folder in")
+}
@@ -0,0 +1,5 @@
+#!/bin/sh
+# TODO: replace with the script this skill runs.
+set -eu
+
+echo "Hello from $(basename "$0") with arguments: $*"
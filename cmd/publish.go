@@ -5,22 +5,46 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/nicholasspencer/gh-skill/internal"
 	"github.com/spf13/cobra"
 )
 
 var (
-	publishPublic   bool
-	publishSecret   bool
-	publishProvider string
+	publishPublic       bool
+	publishSecret       bool
+	publishProvider     string
+	publishHost         string
+	publishNew          bool
+	publishGitignore    bool
+	publishDryRun       bool
+	publishAllowSecrets bool
+	publishBump         string
+	publishMessage      string
+	publishNoLint       bool
 )
 
 var publishCmd = &cobra.Command{
 	Use:   "publish <path>",
 	Short: "Publish a local skill folder as a GitHub Gist",
-	Long:  "Creates a secret (unlisted) gist by default. Use --public to make it discoverable.",
-	Args:  cobra.ExactArgs(1),
+	Long: `Creates a secret (unlisted) gist by default. Use --public to make it discoverable.
+The folder is checked with ` + "`gh skill lint`" + ` first; lint errors block publishing unless
+--no-lint is passed.
+
+The folder remembers where it was published in .gistskill.json, so publishing it again
+updates that same gist or snippet — adding, changing and deleting files — instead of
+creating a new one. Use --new to publish a fresh copy anyway.
+
+Dotfiles and anything matched by a .skillignore file (gitignore syntax) in the folder are
+left out. Files over 1 MB are refused, since the gists API truncates them, and so are
+files that look like they contain credentials unless --allow-secrets is passed.
+
+--bump patch|minor|major increments the semver version in SKILL.md's front matter and adds
+an entry (from --message) to the folder's CHANGELOG.md before publishing; both files are
+put back if publishing fails. Updating a gist
+with a version that is not newer than the one last published from the folder is refused.`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir := args[0]
 		info, err := os.Stat(dir)
@@ -47,43 +71,128 @@ var publishCmd = &cobra.Command{
 		}
 		description = "[gh-skill] " + description
 
+		if !publishNoLint {
+			report, err := internal.LintSkill(dir, publishGitignore)
+			if err != nil {
+				return err
+			}
+			if len(report.Issues) > 0 {
+				printLintReport(report)
+			}
+			if report.Errors() > 0 {
+				return fmt.Errorf("fix the lint errors above, or pass --no-lint to publish anyway")
+			}
+		}
+
 		// Determine visibility: secret by default, --public overrides, --secret is explicit
 		isPublic := false
 		if publishPublic {
 			isPublic = true
 		}
 
+		// A folder published before is updated in place, if it is ours
+		meta, err := internal.ReadSkillMeta(dir)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		var updateProvider internal.Provider
+		if meta != nil && meta.GistID != "" && !publishNew {
+			provider := internal.NewProvider(meta.EffectiveProvider(), meta.Host)
+			if authUser := provider.AuthenticatedUser(); meta.Author == "" || strings.EqualFold(meta.Author, authUser) {
+				updateProvider = provider
+			}
+		}
+
+		version := fm.Version
+		if publishBump != "" {
+			if version, err = internal.BumpVersion(fm.Version, publishBump); err != nil {
+				return err
+			}
+		}
+		if updateProvider != nil && version != "" && meta.Version != "" {
+			if cmp, ok := internal.CompareVersions(version, meta.Version); (ok && cmp <= 0) || (!ok && version == meta.Version) {
+				return fmt.Errorf("version %s is not newer than the published %s; bump it with --bump patch|minor|major", version, meta.Version)
+			}
+		}
+
 		// Collect all files, flattening subdirectories with -- convention
-		files := make(map[string]string)
-		filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
-			if err != nil || fi.IsDir() || strings.HasPrefix(fi.Name(), ".") {
-				return nil
+		collected, err := internal.CollectPublishFiles(dir, skillName, publishGitignore)
+		if err != nil {
+			return err
+		}
+		printPublishFiles(collected)
+		if err := checkSecrets(collected, publishAllowSecrets); err != nil {
+			return err
+		}
+		if publishDryRun {
+			if publishBump != "" {
+				fmt.Printf("Would bump version %s → %s\n", displayVersion(fm.Version), version)
 			}
-			rel, _ := filepath.Rel(dir, path)
-			content, err := os.ReadFile(path)
+			fmt.Println("Dry run: nothing was published.")
+			return nil
+		}
+
+		// The bumped SKILL.md and CHANGELOG.md are kept only if the publish
+		// goes through
+		published := false
+		if publishBump != "" {
+			restore, err := bumpSkillVersion(dir, skillPath, string(skillContent), version)
 			if err != nil {
-				return nil
+				return err
 			}
-			gistName := internal.FlattenFilename(rel)
-			// Rename SKILL.md → <name>.skill.md for the gist
-			if gistName == "SKILL.md" {
-				gistName = internal.SkillFileName(skillName)
+			defer func() {
+				if !published {
+					restore()
+					fmt.Printf("- Restored version %s\n", displayVersion(fm.Version))
+				}
+			}()
+			fmt.Printf("✓ Bumped version %s → %s\n", displayVersion(fm.Version), version)
+			if collected, err = internal.CollectPublishFiles(dir, skillName, publishGitignore); err != nil {
+				return err
 			}
-			files[gistName] = string(content)
-			return nil
-		})
+		}
+		files := internal.PublishPayload(collected)
+
+		if updateProvider != nil {
+			err := republish(dir, meta, updateProvider, description, version, files)
+			published = err == nil
+			return err
+		}
 
 		visibility := "secret"
 		if isPublic {
 			visibility = "public"
 		}
-		provider := internal.ProviderByName(publishProvider)
+		provider := internal.NewProvider(publishProvider, publishHost)
 		fmt.Printf("Publishing %d files as a %s %s snippet...\n", len(files), visibility, provider.Name())
 
 		gist, err := provider.CreateSnippet(description, files, isPublic)
 		if err != nil {
 			return err
 		}
+		published = true
+
+		// Remember the snippet so the next publish updates it. Metadata of an
+		// installed skill that came from someone else is left alone.
+		if meta == nil {
+			now := time.Now().UTC().Format(time.RFC3339)
+			marker := &internal.SkillMeta{
+				Name:        skillName,
+				GistID:      gist.ID,
+				Provider:    provider.Name(),
+				Host:        provider.Host(),
+				CommitSHA:   gist.Revision(),
+				Description: fm.Description,
+				Version:     version,
+				Author:      gist.Owner.Login,
+				GistURL:     gist.HTMLURL,
+				InstalledAt: now,
+				UpdatedAt:   now,
+			}
+			if err := internal.WriteSkillMeta(dir, marker); err != nil {
+				fmt.Printf("⚠ Could not record the published gist: %v\n", err)
+			}
+		}
 
 		fmt.Printf("✓ Published: %s\n", gist.HTMLURL)
 		fmt.Printf("  Install with: gh skill add %s\n", gist.HTMLURL)
@@ -91,8 +200,115 @@ var publishCmd = &cobra.Command{
 	},
 }
 
+// printPublishFiles lists the files about to be uploaded with their sizes.
+func printPublishFiles(files []internal.PublishFile) {
+	total := int64(0)
+	for _, f := range files {
+		total += f.Size
+		marker := ""
+		if f.Binary {
+			marker = " (binary, base64)"
+		}
+		fmt.Printf("  %-40s %10s%s\n", f.Name, internal.FormatSize(f.Size), marker)
+	}
+	fmt.Printf("  %d files, %s\n", len(files), internal.FormatSize(total))
+}
+
+// bumpSkillVersion writes version into the front matter of the SKILL.md at
+// skillPath and records it in the folder's CHANGELOG.md. The returned func
+// puts both files back as they were.
+func bumpSkillVersion(dir, skillPath, skillContent, version string) (func(), error) {
+	changelogPath := filepath.Join(dir, internal.ChangelogFile)
+	changelog, changelogErr := os.ReadFile(changelogPath)
+	restore := func() {
+		os.WriteFile(skillPath, []byte(skillContent), 0644)
+		if os.IsNotExist(changelogErr) {
+			os.Remove(changelogPath)
+		} else if changelogErr == nil {
+			os.WriteFile(changelogPath, changelog, 0644)
+		}
+	}
+
+	updated := internal.SetFrontMatterVersion(skillContent, version)
+	if err := os.WriteFile(skillPath, []byte(updated), 0644); err != nil {
+		restore()
+		return nil, fmt.Errorf("failed to update SKILL.md: %w", err)
+	}
+	message := publishMessage
+	if message == "" {
+		message = "Published version " + version
+	}
+	date := time.Now().Format("2006-01-02")
+	if err := internal.AddChangelogEntry(dir, version, date, message); err != nil {
+		restore()
+		return nil, fmt.Errorf("failed to update %s: %w", internal.ChangelogFile, err)
+	}
+	return restore, nil
+}
+
+// displayVersion shows a missing version as "(none)".
+func displayVersion(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
+
+// checkSecrets blocks publishing files that look like they contain
+// credentials unless allow is set, listing every finding.
+func checkSecrets(files []internal.PublishFile, allow bool) error {
+	findings := internal.ScanSecrets(files)
+	if len(findings) == 0 {
+		return nil
+	}
+	fmt.Printf("⚠ Found %d likely secret(s):\n", len(findings))
+	for _, f := range findings {
+		fmt.Printf("  %s\n", f)
+	}
+	if allow {
+		fmt.Println("  Publishing anyway (--allow-secrets).")
+		return nil
+	}
+	return fmt.Errorf("refusing to publish likely secrets; remove them, exclude the files in %s, or pass --allow-secrets", internal.SkillIgnoreFile)
+}
+
+// republish replaces the files of the snippet recorded in meta with files
+// and records the new revision in dir's .gistskill.json.
+func republish(dir string, meta *internal.SkillMeta, provider internal.Provider, description, version string, files map[string]string) error {
+	fmt.Printf("Updating %s snippet %s with %d files...\n", provider.Name(), meta.GistID, len(files))
+	gist, err := provider.UpdateSnippet(meta.GistID, description, files)
+	if err != nil {
+		return fmt.Errorf("%w (use --new to publish a new copy instead)", err)
+	}
+	if gist.HTMLURL != "" {
+		meta.GistURL = gist.HTMLURL
+	}
+	if rev := gist.Revision(); rev != "" {
+		meta.CommitSHA = rev
+	}
+	meta.Version = version
+	meta.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if err := internal.WriteSkillMeta(dir, meta); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Updated: %s\n", meta.GistURL)
+	if rev := gist.Revision(); rev != "" {
+		fmt.Printf("  Revision: %s\n", rev)
+	}
+	return nil
+}
+
 func init() {
 	publishCmd.Flags().BoolVar(&publishPublic, "public", false, "Create a public gist/snippet")
 	publishCmd.Flags().BoolVar(&publishSecret, "secret", false, "Create a secret (unlisted) gist/snippet (default)")
-	publishCmd.Flags().StringVar(&publishProvider, "provider", "github", "Provider to publish to (github or gitlab)")
+	publishCmd.Flags().StringVar(&publishProvider, "provider", "github", "Provider to publish to (github, gitlab or gitea)")
+	publishCmd.Flags().StringVar(&publishHost, "host", "", "Self-hosted GitLab, GitHub Enterprise or Gitea/Forgejo host (default: gitlab.com / github.com)")
+	publishCmd.Flags().BoolVar(&publishGitignore, "gitignore", false, "Also leave out files matched by the folder's .gitignore")
+	publishCmd.Flags().BoolVar(&publishDryRun, "dry-run", false, "List the files that would be published without publishing")
+	publishCmd.Flags().StringVar(&publishBump, "bump", "", "Bump the version before publishing (patch, minor or major)")
+	publishCmd.Flags().StringVarP(&publishMessage, "message", "m", "", "Changelog entry for --bump")
+	publishCmd.Flags().BoolVar(&publishNoLint, "no-lint", false, "Skip the lint checks run before publishing")
+	publishCmd.Flags().BoolVar(&publishAllowSecrets, "allow-secrets", false, "Publish even if likely secrets are found")
+	publishCmd.Flags().BoolVar(&publishNew, "new", false, "Create a new gist/snippet even if the folder was published before")
 }
@@ -8,8 +8,11 @@ import (
 )
 
 var removeCmd = &cobra.Command{
-	Use:     "remove <name>",
-	Short:   "Remove an installed skill",
+	Use:   "remove <name>",
+	Short: "Remove an installed skill",
+	Long: `Removes an installed skill, its links in tool directories and the files link
+rendered from it. The skill's saved revisions are deleted too, so it cannot be rolled
+back after it is reinstalled.`,
 	Aliases: []string{"rm"},
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -21,3 +24,7 @@ var removeCmd = &cobra.Command{
 		return nil
 	},
 }
+
+func init() {
+	addScopeFlag(removeCmd)
+}
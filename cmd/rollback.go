@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var rollbackTo string
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <name>",
+	Short: "Restore a previously installed revision of a skill",
+	Long:  "Restores the previous revision from history, or the one given with --to. The restored skill is pinned; use `update --force` to return to the latest revision.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		meta, err := internal.RollbackSkill(args[0], rollbackTo)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Rolled back %q to %s (v%s, pinned)\n", meta.Name, internal.ShortSHA(meta.CommitSHA), meta.Version)
+		return nil
+	},
+}
+
+func init() {
+	rollbackCmd.Flags().StringVar(&rollbackTo, "to", "", "Revision SHA to restore (default: previous revision)")
+}
@@ -26,4 +26,14 @@ func init() {
 	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(forkCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(rollbackCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(addCollectionCmd)
+	rootCmd.AddCommand(collectionCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(newCmd)
+	rootCmd.AddCommand(importRulesCmd)
 }
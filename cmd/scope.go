@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+// skillScope is the --scope flag of the commands that manage installed skills.
+var skillScope string
+
+// addScopeFlag adds --scope to cmd and applies it before cmd runs.
+func addScopeFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&skillScope, "scope", "user", "Where skills are installed: user (~/.gistskills) or project (<repo>/.gistskills)")
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return applyScope()
+	}
+}
+
+// applyScope switches to the skills of the enclosing git repository, linked
+// into its .claude/skills, .codex/skills etc., when --scope is project.
+func applyScope() error {
+	switch skillScope {
+	case "", "user":
+		return nil
+	case "project":
+		cwd, _ := os.Getwd()
+		root, err := internal.FindGitRoot(cwd)
+		if err != nil {
+			return fmt.Errorf("--scope project: %w", err)
+		}
+		internal.UseProjectScope(root)
+		return nil
+	}
+	return fmt.Errorf("unknown scope %q (use user or project)", skillScope)
+}
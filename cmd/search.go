@@ -7,14 +7,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var searchProvider string
+var (
+	searchProvider string
+	searchHost     string
+)
 
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search for skills on GitHub Gists and GitLab Snippets",
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		provider := internal.ProviderByName(searchProvider)
+		provider := internal.NewProvider(searchProvider, searchHost)
 		results, err := provider.SearchSnippets(args[0])
 		if err != nil {
 			return err
@@ -32,5 +35,6 @@ var searchCmd = &cobra.Command{
 }
 
 func init() {
-	searchCmd.Flags().StringVar(&searchProvider, "provider", "github", "Provider to search (github, gitlab)")
+	searchCmd.Flags().StringVar(&searchProvider, "provider", "github", "Provider to search (github, gitlab, gitea)")
+	searchCmd.Flags().StringVar(&searchHost, "host", "", "Self-hosted GitLab, GitHub Enterprise or Gitea/Forgejo host")
 }
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nicholasspencer/gh-skill/internal"
+	"github.com/spf13/cobra"
+)
+
+var (
+	syncCheck  bool
+	syncUpdate bool
+	syncYes    bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Install the skills listed in the project's skills.json at their locked revisions",
+	Long: `Reads skills.json and skills.lock from the nearest project directory and makes
+~/.gistskills and the project's tool directories match them exactly.
+
+Entries missing from skills.lock are resolved (to their @<sha> if given, else the
+latest revision) and recorded. Locked entries are installed at their pinned commit
+and verified against the recorded file hashes. Skills the previous lock installed
+that are no longer listed are unlinked from the project and reported, but stay in
+~/.gistskills, which other projects share. Pinning is recorded in skills.lock only.
+
+Use --check in CI to fail when the lockfile and installed skills have diverged.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cwd, _ := os.Getwd()
+		root, err := internal.FindProjectRoot(cwd)
+		if err != nil {
+			return err
+		}
+		manifest, err := internal.LoadManifest(root)
+		if err != nil {
+			return err
+		}
+		lock, err := internal.LoadLockfile(root)
+		if err != nil {
+			return err
+		}
+
+		if syncCheck {
+			problems := checkSync(manifest, lock)
+			if len(problems) > 0 {
+				for _, p := range problems {
+					fmt.Printf("✗ %s\n", p)
+				}
+				return fmt.Errorf("%s and installed skills are out of sync (run `gh skill sync`)", internal.LockFile)
+			}
+			fmt.Printf("✓ %d skill(s) match %s\n", len(lock.Skills), internal.LockFile)
+			return nil
+		}
+
+		newLock := &internal.Lockfile{Version: 1}
+		for i, source := range manifest.Skills {
+			entry, err := syncSkill(source, lock.Find(source))
+			if err != nil || entry == nil {
+				// Skills synced so far stay installed, so the lock must record
+				// them; the rest keep their previous entries
+				for _, rest := range manifest.Skills[i:] {
+					if e := lock.Find(rest); e != nil {
+						newLock.Skills = append(newLock.Skills, *e)
+					}
+				}
+				if err := newLock.Save(root); err != nil {
+					return fmt.Errorf("failed to write %s: %w", internal.LockFile, err)
+				}
+			}
+			if err != nil {
+				return fmt.Errorf("%s: %w", source, err)
+			}
+			if entry == nil {
+				fmt.Println("Aborted.")
+				return nil
+			}
+			newLock.Skills = append(newLock.Skills, *entry)
+		}
+		if err := newLock.Save(root); err != nil {
+			return fmt.Errorf("failed to write %s: %w", internal.LockFile, err)
+		}
+
+		// Skills the previous lock installed that are no longer listed may
+		// still be used by other projects' manifests, so they are only reported
+		for _, name := range droppedSkills(lock, newLock) {
+			fmt.Printf("  - %s is no longer in %s; remove it with `gh skill remove %s` if no other project uses it\n", name, internal.ManifestFile, name)
+		}
+
+		// Project tool directories carry exactly the locked skills
+		wanted := make(map[string]bool)
+		for _, e := range newLock.Skills {
+			wanted[e.Name] = true
+		}
+		for _, dir := range internal.ProjectToolDirs(root) {
+			for _, name := range internal.ManagedLinks(dir) {
+				if !wanted[name] {
+					os.Remove(filepath.Join(dir, name))
+					fmt.Printf("  - Unlinked %s from %s\n", name, dir)
+				}
+			}
+		}
+
+		fmt.Printf("✓ Synced %d skill(s) from %s\n", len(newLock.Skills), filepath.Join(root, internal.LockFile))
+		return nil
+	},
+}
+
+// syncSkill installs one manifest source. When locked is non-nil the locked
+// revision is installed and verified; otherwise the source is resolved afresh.
+// Returns a nil entry if the user declined the trust prompt.
+func syncSkill(source string, locked *internal.LockEntry) (*internal.LockEntry, error) {
+	provider, snippetID, revision := detectSource(source)
+
+	if locked != nil && (revision != "" || !syncUpdate) {
+		if meta, err := internal.GetSkill(locked.Name); err == nil && meta.CommitSHA == locked.CommitSHA {
+			if hashes, err := internal.InstalledFileHashes(locked.Name); err == nil && len(internal.DiffHashes(locked.Files, hashes)) == 0 {
+				fmt.Printf("✓ %s is up to date (%s)\n", locked.Name, internal.ShortSHA(locked.CommitSHA))
+				linkSynced(locked.Name)
+				return locked, nil
+			}
+		}
+		revision = locked.CommitSHA
+	}
+
+	var gist *internal.Gist
+	var err error
+	if revision != "" {
+		gist, err = provider.FetchSnippetRevision(snippetID, revision)
+	} else {
+		gist, err = provider.FetchSnippet(snippetID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if locked != nil && locked.CommitSHA == revision {
+		if diffs := internal.DiffHashes(locked.Files, internal.GistFileHashes(gist)); len(diffs) > 0 {
+			return nil, fmt.Errorf("content of revision %s does not match %s: %v", internal.ShortSHA(revision), internal.LockFile, diffs)
+		}
+	} else {
+		_, skillFile, ok := internal.FindSkillFile(gist.Files)
+		if !ok {
+			return nil, fmt.Errorf("gist does not contain a *.skill.md file")
+		}
+		fm, err := internal.ParseFrontMatter(skillFile.Content)
+		if err != nil {
+			return nil, err
+		}
+		proceed, err := trustGate(provider, gist, fm, syncYes)
+		if err != nil || !proceed {
+			return nil, err
+		}
+	}
+
+	// The revision is pinned by skills.lock, not in the user's install,
+	// which other projects and update --all share
+	meta, err := internal.InstallSkill(gist, provider)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Printf("✓ Installed %q at %s\n", meta.Name, internal.ShortSHA(meta.CommitSHA))
+	linkSynced(meta.Name)
+
+	entry := internal.NewLockEntry(source, gist, meta)
+	return &entry, nil
+}
+
+// linkSynced links a skill into the detected tool directories and the
+// project's own tool directories.
+func linkSynced(name string) {
+	internal.AutoLink(name)
+	cwd, _ := os.Getwd()
+	if root, err := internal.FindProjectRoot(cwd); err == nil {
+		for _, dir := range internal.ProjectToolDirs(root) {
+			internal.LinkSkill(name, dir)
+		}
+	}
+}
+
+// droppedSkills returns the installed skills locked in old but not in current.
+func droppedSkills(old, current *internal.Lockfile) []string {
+	kept := make(map[string]bool)
+	for _, e := range current.Skills {
+		kept[e.Name] = true
+	}
+	var names []string
+	for _, e := range old.Skills {
+		if _, err := internal.GetSkill(e.Name); err == nil && !kept[e.Name] {
+			names = append(names, e.Name)
+		}
+	}
+	return names
+}
+
+// checkSync reports every way the manifest, lockfile and installed skills disagree.
+func checkSync(manifest *internal.Manifest, lock *internal.Lockfile) []string {
+	var problems []string
+	listed := make(map[string]bool)
+	for _, source := range manifest.Skills {
+		listed[source] = true
+		if lock.Find(source) == nil {
+			problems = append(problems, fmt.Sprintf("%s is not in %s", source, internal.LockFile))
+		}
+	}
+	for _, e := range lock.Skills {
+		if !listed[e.Source] {
+			problems = append(problems, fmt.Sprintf("%s is locked but not listed in %s", e.Source, internal.ManifestFile))
+		}
+		meta, err := internal.GetSkill(e.Name)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s is not installed", e.Name))
+			continue
+		}
+		if meta.CommitSHA != e.CommitSHA {
+			problems = append(problems, fmt.Sprintf("%s is at %s, locked at %s", e.Name, internal.ShortSHA(meta.CommitSHA), internal.ShortSHA(e.CommitSHA)))
+			continue
+		}
+		hashes, err := internal.InstalledFileHashes(e.Name)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", e.Name, err))
+			continue
+		}
+		for _, d := range internal.DiffHashes(e.Files, hashes) {
+			problems = append(problems, fmt.Sprintf("%s: %s", e.Name, d))
+		}
+	}
+	return problems
+}
+
+func init() {
+	syncCmd.Flags().BoolVar(&syncCheck, "check", false, "Only verify installed skills against skills.lock; exit nonzero on mismatch")
+	syncCmd.Flags().BoolVar(&syncUpdate, "update", false, "Re-resolve unpinned entries to their latest revision")
+	syncCmd.Flags().BoolVarP(&syncYes, "yes", "y", false, "Skip trust prompt for newly resolved skills")
+}
@@ -1,19 +1,53 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
+	"os"
+	"slices"
+	"strings"
 
 	"github.com/nicholasspencer/gh-skill/internal"
 	"github.com/spf13/cobra"
 )
 
-var updateAll bool
+var (
+	updateAll        bool
+	updateTo         string
+	updateForce      bool
+	updateStat       bool
+	updateDryRun     bool
+	updateYes        bool
+	updateCollection string
+)
 
 var updateCmd = &cobra.Command{
 	Use:   "update [name]",
 	Short: "Update a skill to the latest gist revision",
+	Long: `Updates a skill to the latest gist revision. Use --to <sha> to install and pin an
+exact revision. Pinned skills are skipped unless --force is given, which also unpins them.
+
+The changes are shown as a diff (or a summary with --stat) and must be confirmed.
+If scripts were added or modified, the trust prompt is shown again unless the
+author is trusted. Use --dry-run to only report what would change.
+
+With --collection <name>, the collection's index is fetched again: members are updated,
+new members are installed after a trust prompt and dropped members are removed. Collections
+are installed in the user scope, so --collection cannot be combined with --scope project.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if updateCollection != "" {
+			if updateTo != "" || updateAll || len(args) > 0 {
+				return fmt.Errorf("--collection cannot be combined with a skill name, --to or --all")
+			}
+			if _, ok := internal.ProjectScope(); ok {
+				return fmt.Errorf("--collection cannot be combined with --scope project; collections are installed in the user scope")
+			}
+			return updateCollectionMembers(updateCollection)
+		}
 		if updateAll {
+			if updateTo != "" {
+				return fmt.Errorf("--to cannot be combined with --all")
+			}
 			skills, err := internal.ListSkills()
 			if err != nil {
 				return err
@@ -22,9 +56,9 @@ var updateCmd = &cobra.Command{
 				fmt.Println("No skills installed.")
 				return nil
 			}
-			for _, s := range skills {
-				if err := updateSkill(s.Name, s.GistID, s.EffectiveProvider()); err != nil {
-					fmt.Printf("✗ Failed to update %s: %v\n", s.Name, err)
+			for i := range skills {
+				if err := updateSkill(&skills[i], ""); err != nil {
+					fmt.Printf("✗ Failed to update %s: %v\n", skills[i].Name, err)
 				}
 			}
 			return nil
@@ -38,24 +72,261 @@ var updateCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		return updateSkill(meta.Name, meta.GistID, meta.EffectiveProvider())
+		return updateSkill(meta, updateTo)
 	},
 }
 
-func updateSkill(name, gistID, providerName string) error {
-	provider := internal.ProviderByName(providerName)
-	gist, err := provider.FetchSnippet(gistID)
+// updateSkill reinstalls a skill from its provider. An empty revision means
+// the latest revision; otherwise the skill is installed and pinned at revision.
+func updateSkill(current *internal.SkillMeta, revision string) error {
+	if revision == "" && current.Pinned && !updateForce {
+		fmt.Printf("- Skipped %q (pinned at %s, use --force to update)\n", current.Name, internal.ShortSHA(current.CommitSHA))
+		return nil
+	}
+
+	provider := internal.NewProvider(current.EffectiveProvider(), current.Host)
+	if resolver, ok := provider.(internal.RevisionResolver); ok && revision == "" && !current.Pinned {
+		latest, err := resolver.LatestRevision(current.GistID)
+		if err != nil {
+			return err
+		}
+		if latest == current.CommitSHA {
+			fmt.Printf("✓ %q is already up to date\n", current.Name)
+			return nil
+		}
+	}
+
+	var gist *internal.Gist
+	var err error
+	if revision != "" {
+		gist, err = provider.FetchSnippetRevision(current.GistID, revision)
+	} else {
+		gist, err = provider.FetchSnippet(current.GistID)
+	}
 	if err != nil {
 		return err
 	}
-	meta, err := internal.InstallSkill(gist, provider.Name())
+
+	installed, err := internal.InstalledFiles(current.Name)
 	if err != nil {
 		return err
 	}
+	changes := internal.ChangedFiles(installed, internal.GistInstallFiles(gist))
+	if len(changes) == 0 && gist.Revision() == current.CommitSHA && (revision != "") == current.Pinned {
+		fmt.Printf("✓ %q is already up to date\n", current.Name)
+		return nil
+	}
+	if len(changes) > 0 {
+		printChanges(current.Name, changes)
+	}
+	printVersionChange(current, gist, revision == "")
+	if updateDryRun {
+		fmt.Printf("  (dry run: %d file(s) would change)\n", len(changes))
+		return nil
+	}
+
+	var scripts []string
+	for _, c := range changes {
+		if c.Status != "removed" && internal.IsScriptFile(c.Path) {
+			scripts = append(scripts, c.Path)
+		}
+	}
+	if len(scripts) > 0 {
+		fmt.Printf("⚠️  Scripts added or modified: %s\n", strings.Join(scripts, ", "))
+		_, skillFile, ok := internal.FindSkillFile(gist.Files)
+		if !ok {
+			return fmt.Errorf("gist does not contain a *.skill.md file")
+		}
+		fm, err := internal.ParseFrontMatter(skillFile.Content)
+		if err != nil {
+			return err
+		}
+		proceed, err := trustGate(provider, gist, fm, false)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			fmt.Printf("- Skipped %q\n", current.Name)
+			return nil
+		}
+	}
+	// Trusted authors skip the trust prompt, not the review of their changes
+	if len(changes) > 0 && !updateYes && !confirm(fmt.Sprintf("Apply changes to %q?", current.Name)) {
+		fmt.Printf("- Skipped %q\n", current.Name)
+		return nil
+	}
+
+	meta, err := internal.InstallSkill(gist, provider)
+	if err != nil {
+		return err
+	}
+	if revision != "" {
+		meta.Pinned = true
+		if err := internal.SaveSkillMeta(meta); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Updated %q to v%s (pinned at %s)\n", meta.Name, meta.Version, internal.ShortSHA(meta.CommitSHA))
+		return nil
+	}
 	fmt.Printf("✓ Updated %q to v%s\n", meta.Name, meta.Version)
 	return nil
 }
 
+// updateCollectionMembers refreshes an installed collection from its index.
+func updateCollectionMembers(name string) error {
+	store, err := internal.LoadCollectionStore()
+	if err != nil {
+		return err
+	}
+	installed := store.Find(name)
+	if installed == nil {
+		return fmt.Errorf("collection %q is not installed", name)
+	}
+	c, revision, err := fetchCollection(installed.Source)
+	if err != nil {
+		return err
+	}
+	// Members keep the name the collection was installed under
+	c.Name = installed.Name
+
+	var members []internal.CollectionMember
+	var added []string
+	for _, source := range c.Skills {
+		m := installed.Member(source)
+		if m == nil {
+			added = append(added, source)
+			continue
+		}
+		meta, err := internal.GetSkill(m.Skill)
+		_, snippetID, rev := detectSource(source)
+		if err != nil || snippetID != meta.GistID {
+			// Removed locally, or a repository member moved to another ref
+			added = append(added, source)
+			continue
+		}
+		// A member the collection pinned follows its index: to the new pin,
+		// or back to the latest revision once the index drops the pin
+		if _, _, oldRev := detectSource(m.Source); rev == "" && oldRev != "" {
+			meta.Pinned = false
+		}
+		if err := updateSkill(meta, rev); err != nil {
+			fmt.Printf("✗ Failed to update %s: %v\n", meta.Name, err)
+		}
+		members = append(members, internal.CollectionMember{Source: source, Skill: m.Skill})
+	}
+
+	for _, m := range installed.Members {
+		if slices.ContainsFunc(c.Skills, func(s string) bool { return internal.SameSnippet(s, m.Source) }) {
+			continue
+		}
+		meta, err := internal.GetSkill(m.Skill)
+		if err != nil || !meta.Collections.Has(installed.Name) {
+			continue // already gone, or no longer managed by the collection
+		}
+		if updateDryRun {
+			fmt.Printf("- %q would be removed (no longer in %q)\n", m.Skill, installed.Name)
+			continue
+		}
+		if meta.Collections.Remove(installed.Name); len(meta.Collections) > 0 {
+			// Another installed collection still has it
+			if err := internal.SaveSkillMeta(meta); err != nil {
+				fmt.Printf("✗ Failed to update %s: %v\n", m.Skill, err)
+			}
+			continue
+		}
+		if err := internal.RemoveSkill(m.Skill); err != nil {
+			fmt.Printf("✗ Failed to remove %s: %v\n", m.Skill, err)
+			continue
+		}
+		fmt.Printf("- Removed %q (no longer in %q)\n", m.Skill, installed.Name)
+	}
+
+	if len(added) > 0 {
+		if updateDryRun {
+			fmt.Printf("  (dry run: %d new member(s) would be installed)\n", len(added))
+			return nil
+		}
+		newMembers, proceed, err := installCollectionMembers(c, added, false)
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			fmt.Printf("- Skipped %d new member(s) of %q\n", len(added), installed.Name)
+		}
+		members = append(members, newMembers...)
+	}
+	if updateDryRun {
+		return nil
+	}
+
+	installed.Revision = revision
+	installed.Members = members
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("failed to save collections: %w", err)
+	}
+	fmt.Printf("✓ Updated collection %q (%d skill(s))\n", installed.Name, len(members))
+	return nil
+}
+
+// printVersionChange reports the front matter version an update moves to,
+// warning when a latest-revision update would move to an older semver
+// version.
+func printVersionChange(current *internal.SkillMeta, gist *internal.Gist, latest bool) {
+	_, skillFile, ok := internal.FindSkillFile(gist.Files)
+	if !ok {
+		return
+	}
+	fm, err := internal.ParseFrontMatter(skillFile.Content)
+	if err != nil || fm.Version == "" || fm.Version == current.Version {
+		return
+	}
+	from := displayVersion(current.Version)
+	cmp, ok := internal.CompareVersions(fm.Version, current.Version)
+	switch {
+	case ok && cmp < 0 && latest:
+		fmt.Printf("⚠️  Version goes back from %s to %s\n", from, fm.Version)
+	case ok && cmp == 0:
+		// Same version written differently, e.g. 1.0 and 1.0.0
+	default:
+		fmt.Printf("  Version: %s → %s\n", from, fm.Version)
+	}
+}
+
+// printChanges prints a unified diff of the changes, or a per-file summary with --stat.
+func printChanges(name string, changes []internal.FileChange) {
+	fmt.Printf("Changes to %q:\n", name)
+	if !updateStat {
+		for _, c := range changes {
+			fmt.Print(c.Unified())
+		}
+		return
+	}
+	var totalIns, totalDel int
+	for _, c := range changes {
+		ins, del := c.Stat()
+		totalIns += ins
+		totalDel += del
+		fmt.Printf("  %-40s %-8s +%d -%d\n", c.Path, c.Status, ins, del)
+	}
+	fmt.Printf("  %d file(s) changed, %d insertion(s)(+), %d deletion(s)(-)\n", len(changes), totalIns, totalDel)
+}
+
+// confirm asks a yes/no question on stdin, defaulting to no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	reader := bufio.NewReader(os.Stdin)
+	input, _ := reader.ReadString('\n')
+	input = strings.TrimSpace(strings.ToLower(input))
+	return input == "y" || input == "yes"
+}
+
 func init() {
 	updateCmd.Flags().BoolVar(&updateAll, "all", false, "Update all installed skills")
+	updateCmd.Flags().StringVar(&updateTo, "to", "", "Install and pin an exact revision SHA")
+	updateCmd.Flags().BoolVar(&updateForce, "force", false, "Update pinned skills to the latest revision (unpins them)")
+	updateCmd.Flags().BoolVar(&updateStat, "stat", false, "Show a per-file summary instead of a full diff")
+	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Only report what would change")
+	updateCmd.Flags().StringVar(&updateCollection, "collection", "", "Update every member of an installed collection")
+	updateCmd.Flags().BoolVarP(&updateYes, "yes", "y", false, "Apply changes without confirmation (changed scripts still require trust)")
+	addScopeFlag(updateCmd)
 }
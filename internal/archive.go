@@ -0,0 +1,212 @@
+package internal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ArchiveProvider implements Provider for skills packed as .tar.gz, .tgz,
+// .tar or .zip archives, read from a local path, a file:// URL or an
+// http(s) URL.
+//
+// Snippet IDs are the archive's http(s) URL or absolute path. Revisions are a
+// digest of the skill's files, so update reinstalls whenever the archive
+// changes.
+type ArchiveProvider struct {
+	HTTPClient *http.Client
+}
+
+func (p *ArchiveProvider) Name() string { return "archive" }
+
+func (p *ArchiveProvider) Host() string { return "" }
+
+var archiveExts = []string{".tar.gz", ".tgz", ".tar", ".zip"}
+
+// archiveExt returns the archive extension of input's path (ignoring any
+// query or fragment), or "" if it is not an archive.
+func archiveExt(input string) string {
+	name := strings.ToLower(input)
+	if strings.Contains(name, "://") {
+		if i := strings.IndexAny(name, "?#"); i >= 0 {
+			name = name[:i]
+		}
+	}
+	for _, ext := range archiveExts {
+		if strings.HasSuffix(name, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// isArchive reports whether input names an archive this provider can read.
+func isArchive(input string) bool {
+	return archiveExt(input) != ""
+}
+
+// archiveID returns the snippet ID for an archive: URLs are kept as they are,
+// local paths (including file:// URLs) are made absolute.
+func archiveID(input string) string {
+	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
+		return input
+	}
+	if p, ok := localPath(input); ok {
+		return p
+	}
+	if p, err := filepath.Abs(input); err == nil {
+		return p
+	}
+	return input
+}
+
+func (p *ArchiveProvider) read(id string) ([]byte, error) {
+	if strings.HasPrefix(id, "http://") || strings.HasPrefix(id, "https://") {
+		client := p.HTTPClient
+		if client == nil {
+			client = &http.Client{Timeout: 60 * time.Second}
+		}
+		data, err := doRequest(client, "GET", id, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", id, err)
+		}
+		return data, nil
+	}
+	return os.ReadFile(id)
+}
+
+func (p *ArchiveProvider) FetchSnippet(id string) (*Gist, error) {
+	data, err := p.read(id)
+	if err != nil {
+		return nil, err
+	}
+	var files map[string]string
+	switch archiveExt(id) {
+	case ".zip":
+		files, err = readZip(data)
+	case ".tar":
+		files, err = readTar(bytes.NewReader(data))
+	case ".tar.gz", ".tgz":
+		var zr *gzip.Reader
+		if zr, err = gzip.NewReader(bytes.NewReader(data)); err == nil {
+			files, err = readTar(zr)
+		}
+	default:
+		err = fmt.Errorf("unsupported archive format")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", id, err)
+	}
+
+	g, err := gistFromFiles(id, files)
+	if err != nil {
+		return nil, err
+	}
+	g.HTMLURL = id
+	if !strings.Contains(id, "://") {
+		g.HTMLURL = "file://" + filepath.ToSlash(id)
+	}
+	return g, nil
+}
+
+func (p *ArchiveProvider) FetchSnippetRevision(id, revision string) (*Gist, error) {
+	g, err := p.FetchSnippet(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDigest(g, revision); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (p *ArchiveProvider) CreateSnippet(description string, files map[string]string, public bool) (*Gist, error) {
+	return nil, fmt.Errorf("publishing to an archive is not supported")
+}
+
+func (p *ArchiveProvider) UpdateSnippet(id, description string, files map[string]string) (*Gist, error) {
+	return nil, fmt.Errorf("publishing to an archive is not supported")
+}
+
+func (p *ArchiveProvider) SearchSnippets(query string) ([]Gist, error) {
+	return nil, fmt.Errorf("searching is not supported for archives")
+}
+
+func (p *ArchiveProvider) AuthenticatedUser() string { return "" }
+
+// archiveName normalizes an archive entry name to a slash-separated relative
+// path. Names that escape the archive root are kept as-is for CheckGistFiles
+// to reject.
+func archiveName(name string) string {
+	clean := path.Clean(strings.TrimPrefix(name, "./"))
+	if clean == "." || strings.HasPrefix(clean, "../") || strings.HasPrefix(clean, "/") {
+		return name
+	}
+	return clean
+}
+
+// readTar reads the regular files of a tar stream, up to maxSkillSize in total.
+func readTar(r io.Reader) (map[string]string, error) {
+	files := make(map[string]string)
+	tr := tar.NewReader(r)
+	total := int64(0)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue // directories, links and devices are never part of a skill
+		}
+		if total += hdr.Size; total > maxSkillSize {
+			return nil, fmt.Errorf("archive is larger than %d MB", maxSkillSize>>20)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, hdr.Size))
+		if err != nil {
+			return nil, err
+		}
+		files[archiveName(hdr.Name)] = string(data)
+	}
+}
+
+// readZip reads the regular files of a zip archive, up to maxSkillSize in total.
+func readZip(data []byte) (map[string]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]string)
+	total := int64(0)
+	for _, f := range zr.File {
+		if !f.Mode().IsRegular() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		// Don't trust the declared size: read at most what is left of the budget
+		content, err := io.ReadAll(io.LimitReader(rc, maxSkillSize-total+1))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if total += int64(len(content)); total > maxSkillSize {
+			return nil, fmt.Errorf("archive is larger than %d MB", maxSkillSize>>20)
+		}
+		files[archiveName(f.Name)] = string(content)
+	}
+	return files, nil
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var archiveFiles = []struct{ name, content string }{
+	{"weather-1.0/SKILL.md", "---\nname: weather\n---\n"},
+	{"weather-1.0/scripts/fetch.sh", "echo"},
+	{"weather-1.0/../escape.sh", "rm -rf"},
+}
+
+func tarGz(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	tw.WriteHeader(&tar.Header{Name: "weather-1.0/", Typeflag: tar.TypeDir, Mode: 0755})
+	tw.WriteHeader(&tar.Header{Name: "weather-1.0/link", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"})
+	for _, f := range archiveFiles {
+		tw.WriteHeader(&tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(f.content))})
+		tw.Write([]byte(f.content))
+	}
+	tw.Close()
+	zw.Close()
+	return buf.Bytes()
+}
+
+func zipData(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, f := range archiveFiles {
+		w, _ := zw.Create(f.name)
+		w.Write([]byte(f.content))
+	}
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestArchiveProvider_FetchSnippet(t *testing.T) {
+	dir := t.TempDir()
+	tgz := filepath.Join(dir, "weather.tar.gz")
+	os.WriteFile(tgz, tarGz(t), 0644)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(zipData(t))
+	}))
+	t.Cleanup(srv.Close)
+
+	p := &ArchiveProvider{}
+	var revisions []string
+	for _, id := range []string{tgz, srv.URL + "/weather.zip"} {
+		g, err := p.FetchSnippet(id)
+		if err != nil {
+			t.Fatalf("FetchSnippet(%q) error: %v", id, err)
+		}
+		if len(g.Files) != 2 || g.Files["scripts/fetch.sh"].Content != "echo" || g.RepoPath != "weather-1.0" {
+			t.Errorf("FetchSnippet(%q) = %+v", id, g)
+		}
+		revisions = append(revisions, g.Revision())
+	}
+	if revisions[0] != revisions[1] {
+		t.Errorf("revisions differ for the same content: %v", revisions)
+	}
+
+	if _, err := p.FetchSnippetRevision(tgz, "deadbeef"); err == nil {
+		t.Error("FetchSnippetRevision(stale) = nil error")
+	}
+}
+
+func TestArchiveExt(t *testing.T) {
+	tests := map[string]string{
+		"skill.tar.gz":                ".tar.gz",
+		"SKILL.TGZ":                   ".tgz",
+		"https://host/skill.zip?dl=1": ".zip",
+		"https://host/skill.zip#top":  ".zip",
+		"/tmp/skill.tar":              ".tar",
+		"https://host/skill.zip/page": "",
+		"team/skills":                 "",
+	}
+	for input, want := range tests {
+		if got := archiveExt(input); got != want {
+			t.Errorf("archiveExt(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
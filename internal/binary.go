@@ -0,0 +1,72 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// BinarySuffix marks a gist file holding the base64 encoding of a binary or
+// non-UTF-8 file. Gists only store UTF-8 text, so publish encodes such files
+// as <name>.b64 and install decodes them back to <name>.
+const BinarySuffix = ".b64"
+
+// base64LineLength wraps encoded files so they stay readable in the gist UI.
+const base64LineLength = 76
+
+// IsBinary reports whether content cannot be stored as gist text: it holds
+// NUL bytes or is not valid UTF-8.
+func IsBinary(content []byte) bool {
+	return bytes.IndexByte(content, 0) >= 0 || !utf8.Valid(content)
+}
+
+// IsBinaryName reports whether a gist filename carries the BinarySuffix.
+func IsBinaryName(filename string) bool {
+	return strings.HasSuffix(filename, BinarySuffix) && len(filename) > len(BinarySuffix)
+}
+
+// EncodeBinary returns content base64-encoded in lines of base64LineLength.
+func EncodeBinary(content []byte) string {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	var b strings.Builder
+	for len(encoded) > base64LineLength {
+		b.WriteString(encoded[:base64LineLength])
+		b.WriteByte('\n')
+		encoded = encoded[base64LineLength:]
+	}
+	b.WriteString(encoded)
+	b.WriteByte('\n')
+	return b.String()
+}
+
+// DecodeBinary decodes content written by EncodeBinary, ignoring line breaks.
+func DecodeBinary(content string) (string, error) {
+	clean := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' || r == ' ' || r == '\t' {
+			return -1
+		}
+		return r
+	}, content)
+	data, err := base64.StdEncoding.DecodeString(clean)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// FileContent returns the content a gist file is installed with: encoded
+// binary files of gist-style snippets are decoded, everything else is
+// returned as fetched.
+func (g *Gist) FileContent(filename string) (string, error) {
+	content := g.Files[filename].Content
+	if g.PathNames || !IsBinaryName(filename) {
+		return content, nil
+	}
+	data, err := DecodeBinary(content)
+	if err != nil {
+		return "", fmt.Errorf("%s is not valid base64: %w", filename, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"# Hello\n", false},
+		{"héllo wörld", false},
+		{"\x89PNG\r\n\x1a\n\x00\x00", true},
+		{"caf\xe9", true}, // Latin-1
+	}
+	for _, tt := range tests {
+		if got := IsBinary([]byte(tt.content)); got != tt.want {
+			t.Errorf("IsBinary(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeBinary_RoundTrip(t *testing.T) {
+	data := strings.Repeat("\x00\x01\xfe\xff", 100)
+	encoded := EncodeBinary([]byte(data))
+	for _, line := range strings.Split(strings.TrimSpace(encoded), "\n") {
+		if len(line) > base64LineLength {
+			t.Fatalf("line of %d chars, want at most %d", len(line), base64LineLength)
+		}
+	}
+	decoded, err := DecodeBinary(encoded)
+	if err != nil {
+		t.Fatalf("DecodeBinary() error: %v", err)
+	}
+	if decoded != data {
+		t.Error("round trip changed the content")
+	}
+}
+
+func TestInstallSkill_DecodesBinaryFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	png := "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	g := testGist("aaa111", "body")
+	g.Files["references--diagram.png.b64"] = GistFile{Content: EncodeBinary([]byte(png))}
+	if _, err := InstallSkill(g); err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join(SkillsBasePath(), "demo", "references", "diagram.png"))
+	if err != nil {
+		t.Fatalf("decoded file missing: %v", err)
+	}
+	if string(got) != png {
+		t.Errorf("diagram.png = %q, want %q", got, png)
+	}
+
+	g = testGist("bbb222", "body")
+	g.Files["broken.bin.b64"] = GistFile{Content: "not base64!"}
+	if _, err := InstallSkill(g); err == nil || !strings.Contains(err.Error(), "broken.bin.b64") {
+		t.Errorf("InstallSkill() with invalid base64 error = %v", err)
+	}
+}
+
+func TestCollectPublishFiles_EncodesBinary(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"SKILL.md":            "---\nname: demo\n---\n",
+		"references/logo.png": "\x89PNG\x00\x01",
+	})
+	files, err := CollectPublishFiles(dir, "demo", false)
+	if err != nil {
+		t.Fatalf("CollectPublishFiles() error: %v", err)
+	}
+	f := files[1]
+	if f.Name != "references--logo.png.b64" || !f.Binary || f.Size != 6 {
+		t.Fatalf("binary file = %+v", f)
+	}
+	if decoded, _ := DecodeBinary(f.Content); decoded != "\x89PNG\x00\x01" {
+		t.Errorf("encoded content decodes to %q", decoded)
+	}
+}
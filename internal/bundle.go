@@ -0,0 +1,236 @@
+package internal
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// bundleManifestFile is the name of the manifest at the root of a bundle.
+const bundleManifestFile = "bundle.json"
+
+// BundleManifest describes the contents of a skill bundle: a .tar.gz holding
+// bundle.json and skills/<name>/ for each skill, metadata included.
+type BundleManifest struct {
+	Version   int           `json:"version"`
+	CreatedAt string        `json:"created_at"`
+	Skills    []BundleSkill `json:"skills"`
+	// TrustedAuthors are the trust store entries for the bundled skills' authors.
+	TrustedAuthors []TrustedAuthor `json:"trusted_authors,omitempty"`
+}
+
+// BundleSkill is a bundled skill and the tools it was linked to.
+type BundleSkill struct {
+	Name string `json:"name"`
+	// Links are tool names as accepted by ToolDirByName.
+	Links []string `json:"links,omitempty"`
+}
+
+// SkillLinks returns the names of the known tools whose skill directory
+// links to the installed skill.
+func SkillLinks(name string) []string {
+	var tools []string
+	for _, t := range KnownTools() {
+		if !filepath.IsAbs(t.Dir) {
+			continue // project-level directories don't carry over
+		}
+		target, err := os.Readlink(filepath.Join(t.Dir, name))
+		if err == nil && target == filepath.Join(SkillsBasePath(), name) {
+			tools = append(tools, t.Name)
+		}
+	}
+	return tools
+}
+
+// ExportBundle writes the named installed skills, their links and the trust
+// store entries for their authors to w as a gzipped tar bundle.
+func ExportBundle(w io.Writer, names []string) (*BundleManifest, error) {
+	manifest := &BundleManifest{
+		Version:   1,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	authors := make(map[string]bool)
+	for _, name := range names {
+		meta, err := GetSkill(name)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Skills = append(manifest.Skills, BundleSkill{Name: meta.Name, Links: SkillLinks(meta.Name)})
+		authors[strings.ToLower(meta.Author)] = true
+	}
+	ts, err := LoadTrustStore()
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range ts.Authors {
+		if authors[strings.ToLower(a.Username)] {
+			manifest.TrustedAuthors = append(manifest.TrustedAuthors, a)
+		}
+	}
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	data, _ := json.MarshalIndent(manifest, "", "  ")
+	if err := writeTarFile(tw, bundleManifestFile, data); err != nil {
+		return nil, err
+	}
+	for _, s := range manifest.Skills {
+		skillDir := filepath.Join(SkillsBasePath(), s.Name)
+		files, err := readSkillFiles(skillDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %q: %w", s.Name, err)
+		}
+		meta, err := os.ReadFile(filepath.Join(skillDir, ".gistskill.json"))
+		if err != nil {
+			return nil, err
+		}
+		files[".gistskill.json"] = string(meta)
+
+		paths := make([]string, 0, len(files))
+		for p := range files {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		for _, p := range paths {
+			if err := writeTarFile(tw, "skills/"+s.Name+"/"+p, []byte(files[p])); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// ImportBundle reads a bundle written by ExportBundle, installs each skill
+// with its original metadata, merges the bundled trust entries into the
+// trust store and recreates the skills' links. Links to tools that cannot be
+// resolved on this machine are reported in the returned errors rather than
+// failing the import.
+func ImportBundle(r io.Reader) (*BundleManifest, []error, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("not a skill bundle: %w", err)
+	}
+	files, err := readTar(zr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	data, ok := files[bundleManifestFile]
+	if !ok {
+		return nil, nil, fmt.Errorf("not a skill bundle: %s is missing", bundleManifestFile)
+	}
+	var manifest BundleManifest
+	if err := json.Unmarshal([]byte(data), &manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s: %w", bundleManifestFile, err)
+	}
+
+	// Install everything before touching the trust store or any links
+	for _, s := range manifest.Skills {
+		if err := importSkill(s.Name, files); err != nil {
+			return nil, nil, fmt.Errorf("failed to import %q: %w", s.Name, err)
+		}
+	}
+
+	if len(manifest.TrustedAuthors) > 0 {
+		ts, err := LoadTrustStore()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, a := range manifest.TrustedAuthors {
+			if !ts.IsTrusted(a.Username) {
+				ts.Authors = append(ts.Authors, a)
+			}
+		}
+		if err := ts.Save(); err != nil {
+			return nil, nil, fmt.Errorf("failed to save trust store: %w", err)
+		}
+	}
+
+	var linkErrs []error
+	for _, s := range manifest.Skills {
+		for _, tool := range s.Links {
+			dir, err := ToolDirByName(tool)
+			if err == nil {
+				err = LinkSkill(s.Name, dir)
+			}
+			if err != nil {
+				linkErrs = append(linkErrs, fmt.Errorf("%s → %s: %w", s.Name, tool, err))
+			}
+		}
+	}
+	return &manifest, linkErrs, nil
+}
+
+// importSkill installs the bundled files of skill name under that name
+// and then restores its bundled metadata verbatim.
+func importSkill(name string, files map[string]string) error {
+	if err := ValidateSkillName(name); err != nil {
+		return err
+	}
+	prefix := "skills/" + name + "/"
+	data, ok := files[prefix+".gistskill.json"]
+	if !ok {
+		return fmt.Errorf("metadata is missing from the bundle")
+	}
+	var meta SkillMeta
+	if err := json.Unmarshal([]byte(data), &meta); err != nil {
+		return fmt.Errorf("invalid metadata: %w", err)
+	}
+	if meta.Name != name {
+		return fmt.Errorf("metadata names skill %q", meta.Name)
+	}
+	// Rendered files belong to the exporting machine's projects
+	meta.Renders = nil
+
+	g := newRepoGist(meta.GistID, meta.Repo, meta.Path, meta.CommitSHA)
+	g.HTMLURL = meta.GistURL
+	g.Owner.Login = meta.Author
+	for p, content := range files {
+		rel, ok := strings.CutPrefix(p, prefix)
+		if !ok || rel == ".gistskill.json" {
+			continue
+		}
+		g.Files[rel] = GistFile{Filename: rel, Content: content}
+	}
+
+	// Refuse files that would install under a different name
+	if _, skillFile, ok := FindSkillFile(g.Files); ok {
+		if fm, err := ParseFrontMatter(skillFile.Content); err == nil && fm.Name != "" && fm.Name != name {
+			return fmt.Errorf("SKILL.md names skill %q", fm.Name)
+		}
+	}
+
+	// Bundles export SKILL.md, so the name the skill was installed under may
+	// only be known from the bundle
+	if _, err := installSkill(g, name, NewProvider(meta.EffectiveProvider(), meta.Host)); err != nil {
+		return err
+	}
+	return SaveSkillMeta(&meta)
+}
@@ -0,0 +1,100 @@
+package internal
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestExportImportBundle(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	os.MkdirAll(filepath.Join(home, ".claude"), 0755)
+
+	g := testGist("aaa111", "body")
+	g.Owner.Login = "nico"
+	g.Files["scripts--run.sh"] = GistFile{Content: "echo"}
+	meta, err := InstallSkill(g)
+	if err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	meta.Pinned = true
+	SaveSkillMeta(meta)
+	AutoLink("demo")
+	ts := &TrustStore{}
+	ts.AddAuthor("nico")
+	ts.AddAuthor("someone-else")
+	ts.Save()
+
+	var buf bytes.Buffer
+	manifest, err := ExportBundle(&buf, []string{"demo"})
+	if err != nil {
+		t.Fatalf("ExportBundle() error: %v", err)
+	}
+	if len(manifest.Skills) != 1 || !reflect.DeepEqual(manifest.Skills[0].Links, []string{"claude-code"}) {
+		t.Errorf("ExportBundle() skills = %+v", manifest.Skills)
+	}
+	if len(manifest.TrustedAuthors) != 1 || manifest.TrustedAuthors[0].Username != "nico" {
+		t.Errorf("ExportBundle() trusted = %+v", manifest.TrustedAuthors)
+	}
+
+	// Restore on a fresh machine
+	home = t.TempDir()
+	t.Setenv("HOME", home)
+	if _, linkErrs, err := ImportBundle(&buf); err != nil || len(linkErrs) > 0 {
+		t.Fatalf("ImportBundle() error: %v %v", err, linkErrs)
+	}
+	got, err := GetSkill("demo")
+	if err != nil {
+		t.Fatalf("GetSkill() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, meta) {
+		t.Errorf("imported meta = %+v, want %+v", got, meta)
+	}
+	files, _ := InstalledFiles("demo")
+	if files["scripts/run.sh"] != "echo" {
+		t.Errorf("imported files = %v", files)
+	}
+	link := filepath.Join(home, ".claude", "skills", "demo")
+	if target, err := os.Readlink(link); err != nil || target != filepath.Join(SkillsBasePath(), "demo") {
+		t.Errorf("link %s = %q, %v", link, target, err)
+	}
+	imported, _ := LoadTrustStore()
+	if !imported.IsTrusted("nico") || imported.IsTrusted("someone-else") {
+		t.Errorf("trust store = %+v", imported.Authors)
+	}
+}
+
+func TestImportBundle_Invalid(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if _, _, err := ImportBundle(bytes.NewReader([]byte("not a bundle"))); err == nil {
+		t.Error("ImportBundle() = nil error, want rejection")
+	}
+}
+
+func TestExportImportBundle_NameFromFileName(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	g := &Gist{
+		ID:      "abc123",
+		Files:   map[string]GistFile{"weather.skill.md": {Content: "# Weather\n"}},
+		History: []GistRevision{{Version: "aaa111"}},
+	}
+	if _, err := InstallSkill(g); err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := ExportBundle(&buf, []string{"weather"}); err != nil {
+		t.Fatalf("ExportBundle() error: %v", err)
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	if _, _, err := ImportBundle(&buf); err != nil {
+		t.Fatalf("ImportBundle() error: %v", err)
+	}
+	skills, _ := ListSkills()
+	if len(skills) != 1 || skills[0].Name != "weather" {
+		t.Errorf("installed skills = %+v, want only weather", skills)
+	}
+}
@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CollectionFile is the index file that makes a gist a collection.
+const CollectionFile = "gistskills.json"
+
+const collectionsFile = "collections.json"
+
+// Collection is the gistskills.json index of a collection: a named list of
+// skill sources (gist URLs or anything else `gh skill add` accepts).
+type Collection struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Skills      []string `json:"skills"`
+}
+
+// ParseCollection reads the gistskills.json index from a fetched gist.
+func ParseCollection(g *Gist) (*Collection, error) {
+	f, ok := g.Files[CollectionFile]
+	if !ok {
+		return nil, fmt.Errorf("%s does not contain a %s file", g.ID, CollectionFile)
+	}
+	var c Collection
+	if err := json.Unmarshal([]byte(f.Content), &c); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", CollectionFile, err)
+	}
+	if strings.TrimSpace(c.Name) == "" {
+		return nil, fmt.Errorf("invalid %s: missing name", CollectionFile)
+	}
+	return &c, nil
+}
+
+// CollectionMember maps a source listed in a collection to the skill it
+// installed.
+type CollectionMember struct {
+	Source string `json:"source"`
+	Skill  string `json:"skill"`
+}
+
+// InstalledCollection records where an installed collection came from and
+// which skills it installed, so update can add and remove members.
+type InstalledCollection struct {
+	Name     string             `json:"name"`
+	Source   string             `json:"source"`
+	Revision string             `json:"revision,omitempty"`
+	Members  []CollectionMember `json:"members"`
+}
+
+// Member returns the member installed from source, if any. Sources match
+// by snippet whatever revision they pin, so a pin bump updates the member.
+func (c *InstalledCollection) Member(source string) *CollectionMember {
+	for i := range c.Members {
+		if SameSnippet(c.Members[i].Source, source) {
+			return &c.Members[i]
+		}
+	}
+	return nil
+}
+
+// SameSnippet reports whether two sources name the same snippet, ignoring
+// the revision either pins.
+func SameSnippet(a, b string) bool {
+	key := func(source string) string {
+		ref, _ := SplitRevision(source)
+		p, id := DetectProvider(ref)
+		return p.Name() + "|" + p.Host() + "|" + id
+	}
+	return key(a) == key(b)
+}
+
+// CollectionStore is ~/.gistskills/collections.json. Collections install
+// into the user scope only, so it is a user file whatever the scope.
+type CollectionStore struct {
+	Collections []InstalledCollection `json:"collections"`
+}
+
+func collectionStorePath() string {
+	return filepath.Join(userBasePath(), collectionsFile)
+}
+
+// LoadCollectionStore reads the installed collections file.
+func LoadCollectionStore() (*CollectionStore, error) {
+	data, err := os.ReadFile(collectionStorePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &CollectionStore{}, nil
+		}
+		return nil, err
+	}
+	var s CollectionStore
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Save writes the collection store to disk.
+func (s *CollectionStore) Save() error {
+	if err := os.MkdirAll(userBasePath(), 0755); err != nil {
+		return err
+	}
+	data, _ := json.MarshalIndent(s, "", "  ")
+	return os.WriteFile(collectionStorePath(), data, 0644)
+}
+
+// Find returns the installed collection with the given name (case-insensitive).
+func (s *CollectionStore) Find(name string) *InstalledCollection {
+	for i := range s.Collections {
+		if strings.EqualFold(s.Collections[i].Name, name) {
+			return &s.Collections[i]
+		}
+	}
+	return nil
+}
+
+// Put adds c, replacing any installed collection with the same name.
+func (s *CollectionStore) Put(c InstalledCollection) {
+	if existing := s.Find(c.Name); existing != nil {
+		*existing = c
+		return
+	}
+	s.Collections = append(s.Collections, c)
+}
+
+const authoredCollectionsFile = "authored-collections.json"
+
+// AuthoredCollection is a collection index maintained with `gh skill
+// collection`, and the snippet it was last published to.
+type AuthoredCollection struct {
+	Collection
+	Provider string `json:"provider,omitempty"`
+	Host     string `json:"host,omitempty"`
+	GistID   string `json:"gist_id,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// Add appends source unless it is already listed, reporting whether it was added.
+func (c *AuthoredCollection) Add(source string) bool {
+	for _, s := range c.Skills {
+		if s == source {
+			return false
+		}
+	}
+	c.Skills = append(c.Skills, source)
+	return true
+}
+
+// Remove drops every listed source for which match returns true and reports
+// how many were dropped.
+func (c *AuthoredCollection) Remove(match func(source string) bool) int {
+	kept := c.Skills[:0]
+	for _, s := range c.Skills {
+		if !match(s) {
+			kept = append(kept, s)
+		}
+	}
+	removed := len(c.Skills) - len(kept)
+	c.Skills = kept
+	return removed
+}
+
+// Index returns the gistskills.json content for the collection.
+func (c *AuthoredCollection) Index() string {
+	idx := c.Collection
+	if idx.Skills == nil {
+		idx.Skills = []string{}
+	}
+	data, _ := json.MarshalIndent(idx, "", "  ")
+	return string(data) + "\n"
+}
+
+// AuthoredCollectionStore is ~/.gistskills/authored-collections.json, a user
+// file like templates and trusted authors.
+type AuthoredCollectionStore struct {
+	Collections []AuthoredCollection `json:"collections"`
+}
+
+func authoredCollectionStorePath() string {
+	return filepath.Join(userBasePath(), authoredCollectionsFile)
+}
+
+// LoadAuthoredCollectionStore reads the authored collections file.
+func LoadAuthoredCollectionStore() (*AuthoredCollectionStore, error) {
+	data, err := os.ReadFile(authoredCollectionStorePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &AuthoredCollectionStore{}, nil
+		}
+		return nil, err
+	}
+	var s AuthoredCollectionStore
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Save writes the authored collection store to disk.
+func (s *AuthoredCollectionStore) Save() error {
+	if err := os.MkdirAll(userBasePath(), 0755); err != nil {
+		return err
+	}
+	data, _ := json.MarshalIndent(s, "", "  ")
+	return os.WriteFile(authoredCollectionStorePath(), data, 0644)
+}
+
+// Find returns the authored collection with the given name (case-insensitive).
+func (s *AuthoredCollectionStore) Find(name string) *AuthoredCollection {
+	for i := range s.Collections {
+		if strings.EqualFold(s.Collections[i].Name, name) {
+			return &s.Collections[i]
+		}
+	}
+	return nil
+}
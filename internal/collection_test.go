@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseCollection(t *testing.T) {
+	g := &Gist{ID: "c0ll", Files: map[string]GistFile{
+		CollectionFile: {Content: `{"name":"Productivity Pack","skills":["https://gist.github.com/u/abc123","team/skills/weather@v1"]}`},
+	}}
+	c, err := ParseCollection(g)
+	if err != nil {
+		t.Fatalf("ParseCollection() error: %v", err)
+	}
+	if c.Name != "Productivity Pack" || len(c.Skills) != 2 {
+		t.Errorf("ParseCollection() = %+v", c)
+	}
+
+	for name, files := range map[string]map[string]GistFile{
+		"missing index": {"demo.skill.md": {Content: "hi"}},
+		"invalid json":  {CollectionFile: {Content: "{"}},
+		"missing name":  {CollectionFile: {Content: `{"skills":[]}`}},
+	} {
+		if _, err := ParseCollection(&Gist{ID: "x", Files: files}); err == nil {
+			t.Errorf("ParseCollection(%s) = nil error", name)
+		}
+	}
+}
+
+func TestCollectionStore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	s, err := LoadCollectionStore()
+	if err != nil {
+		t.Fatalf("LoadCollectionStore() error: %v", err)
+	}
+	s.Put(InstalledCollection{Name: "Pack", Source: "c0ll", Members: []CollectionMember{{Source: "abc123", Skill: "demo"}}})
+	s.Put(InstalledCollection{Name: "pack", Source: "c0ll2"})
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, _ := LoadCollectionStore()
+	if len(loaded.Collections) != 1 {
+		t.Fatalf("Collections = %+v, want one entry replaced by name", loaded.Collections)
+	}
+	c := loaded.Find("PACK")
+	if c == nil || c.Source != "c0ll2" {
+		t.Errorf("Find(PACK) = %+v", c)
+	}
+	if c.Member("abc123") != nil {
+		t.Error("Member() found a member of the replaced entry")
+	}
+}
+
+func TestCollectionStores_UserScope(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	UseProjectScope(t.TempDir())
+	t.Cleanup(func() { UseProjectScope("") })
+
+	want := filepath.Join(home, SkillsDir)
+	if got := filepath.Dir(collectionStorePath()); got != want {
+		t.Errorf("collections.json in %s, want %s", got, want)
+	}
+	if got := filepath.Dir(authoredCollectionStorePath()); got != want {
+		t.Errorf("authored-collections.json in %s, want %s", got, want)
+	}
+}
+
+func TestInstalledCollectionMember(t *testing.T) {
+	c := InstalledCollection{Members: []CollectionMember{
+		{Source: "https://gist.github.com/u/abc123@aaa111", Skill: "demo"},
+		{Source: "https://gitlab.com/-/snippets/42", Skill: "lab"},
+	}}
+	for source, want := range map[string]string{
+		"abc123":                              "demo",
+		"abc123@bbb222":                       "demo",
+		"https://gitlab.com/-/snippets/42@v2": "lab",
+		"def456":                              "",
+	} {
+		got := ""
+		if m := c.Member(source); m != nil {
+			got = m.Skill
+		}
+		if got != want {
+			t.Errorf("Member(%q) = %q, want %q", source, got, want)
+		}
+	}
+}
+
+func TestCollectionNames(t *testing.T) {
+	var legacy, list SkillMeta
+	if err := json.Unmarshal([]byte(`{"collection":"Pack"}`), &legacy); err != nil {
+		t.Fatalf("Unmarshal(string) error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"collection":["Pack","Tools"]}`), &list); err != nil {
+		t.Fatalf("Unmarshal(list) error: %v", err)
+	}
+	if !legacy.Collections.Has("pack") || len(legacy.Collections) != 1 {
+		t.Errorf("legacy Collections = %q", legacy.Collections)
+	}
+	list.Collections.Add("tools")
+	list.Collections.Remove("PACK")
+	if len(list.Collections) != 1 || list.Collections[0] != "Tools" {
+		t.Errorf("Collections = %q, want [Tools]", list.Collections)
+	}
+}
+
+func TestInstallSkill_KeepsCollection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	meta, err := InstallSkill(testGist("aaa111", "first"))
+	if err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	meta.Collections.Add("Pack")
+	SaveSkillMeta(meta)
+
+	updated, err := InstallSkill(testGist("bbb222", "second"))
+	if err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	if !updated.Collections.Has("Pack") {
+		t.Errorf("Collections = %q after update, want Pack", updated.Collections)
+	}
+}
+
+func TestAuthoredCollection(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	s, _ := LoadAuthoredCollectionStore()
+	s.Collections = append(s.Collections, AuthoredCollection{Collection: Collection{Name: "Team Pack"}})
+	c := s.Find("team pack")
+	if !c.Add("https://gist.github.com/u/abc123") || !c.Add("https://gist.github.com/u/def456@sha1") {
+		t.Fatal("Add() = false for new sources")
+	}
+	if c.Add("https://gist.github.com/u/abc123") {
+		t.Error("Add() = true for a duplicate source")
+	}
+	if n := c.Remove(func(s string) bool { return s == "https://gist.github.com/u/abc123" }); n != 1 {
+		t.Errorf("Remove() = %d, want 1", n)
+	}
+	c.GistID = "c0ll"
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, _ := LoadAuthoredCollectionStore()
+	got := loaded.Find("Team Pack")
+	if got == nil || got.GistID != "c0ll" || len(got.Skills) != 1 {
+		t.Fatalf("Find() = %+v", got)
+	}
+	idx, err := ParseCollection(&Gist{Files: map[string]GistFile{CollectionFile: {Content: got.Index()}}})
+	if err != nil || idx.Name != "Team Pack" || idx.Skills[0] != "https://gist.github.com/u/def456@sha1" {
+		t.Errorf("Index() round trip = %+v, %v", idx, err)
+	}
+}
@@ -0,0 +1,58 @@
+package internal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const configFile = "config.json"
+
+// DefaultHistoryRetention is how many previous revisions are kept per skill
+// when config.json does not say otherwise.
+const DefaultHistoryRetention = 5
+
+// Config is the user configuration stored in ~/.gistskills/config.json.
+type Config struct {
+	// HistoryRetention is the number of previous revisions kept per skill.
+	// Zero means the default; a negative value disables history.
+	HistoryRetention int `json:"history_retention,omitempty"`
+	// GitHubAPIURL overrides the GitHub REST API base URL.
+	GitHubAPIURL string `json:"github_api_url,omitempty"`
+	// GitHubHosts lists GitHub Enterprise Server hosts (e.g. "ghe.example.com")
+	// whose gist URLs should be recognized.
+	GitHubHosts []string `json:"github_hosts,omitempty"`
+	// GitLabHosts lists self-hosted GitLab hosts whose snippet URLs should
+	// be recognized.
+	GitLabHosts []string `json:"gitlab_hosts,omitempty"`
+	// GiteaHosts lists Gitea/Forgejo hosts whose repository URLs should be
+	// installable as skills.
+	GiteaHosts []string `json:"gitea_hosts,omitempty"`
+}
+
+// LoadConfig reads config.json, returning defaults if it does not exist.
+func LoadConfig() (*Config, error) {
+	data, err := os.ReadFile(filepath.Join(userBasePath(), configFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Config{}, nil
+		}
+		return nil, err
+	}
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// EffectiveHistoryRetention returns the retention count with defaults applied.
+func (c *Config) EffectiveHistoryRetention() int {
+	switch {
+	case c.HistoryRetention == 0:
+		return DefaultHistoryRetention
+	case c.HistoryRetention < 0:
+		return 0
+	}
+	return c.HistoryRetention
+}
@@ -0,0 +1,208 @@
+package internal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each hunk.
+const diffContext = 3
+
+// maxDiffCells bounds the LCS table; larger files are diffed as a full replacement.
+const maxDiffCells = 4_000_000
+
+// FileChange describes how one file differs between two skill revisions.
+type FileChange struct {
+	Path   string
+	Status string // "added", "removed" or "modified"
+	Old    string
+	New    string
+	// Binary is set when either side is binary; such files are not diffed
+	// line by line.
+	Binary bool
+}
+
+// ChangedFiles compares two path → content maps and returns the changed
+// files sorted by path.
+func ChangedFiles(old, new map[string]string) []FileChange {
+	var changes []FileChange
+	for path, o := range old {
+		n, ok := new[path]
+		switch {
+		case !ok:
+			changes = append(changes, FileChange{Path: path, Status: "removed", Old: o, Binary: IsBinary([]byte(o))})
+		case n != o:
+			changes = append(changes, FileChange{Path: path, Status: "modified", Old: o, New: n, Binary: IsBinary([]byte(o)) || IsBinary([]byte(n))})
+		}
+	}
+	for path, n := range new {
+		if _, ok := old[path]; !ok {
+			changes = append(changes, FileChange{Path: path, Status: "added", New: n, Binary: IsBinary([]byte(n))})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// Stat returns the number of inserted and deleted lines in the change, or
+// zero for both if it is binary.
+func (c FileChange) Stat() (int, int) {
+	var ins, del int
+	if c.Binary {
+		return 0, 0
+	}
+	for _, op := range lineOps(splitLines(c.Old), splitLines(c.New)) {
+		switch op.kind {
+		case '+':
+			ins++
+		case '-':
+			del++
+		}
+	}
+	return ins, del
+}
+
+// Unified renders the change as a unified diff, or a one-line note if it is
+// binary.
+func (c FileChange) Unified() string {
+	if c.Binary {
+		return fmt.Sprintf("Binary file %s differs\n", c.Path)
+	}
+	var b strings.Builder
+	oldName, newName := "a/"+c.Path, "b/"+c.Path
+	if c.Status == "added" {
+		oldName = "/dev/null"
+	}
+	if c.Status == "removed" {
+		newName = "/dev/null"
+	}
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+
+	ops := lineOps(splitLines(c.Old), splitLines(c.New))
+	for _, h := range hunks(ops) {
+		oldStart, newStart, oldCount, newCount := 1, 1, 0, 0
+		for _, op := range ops[:h[0]] {
+			if op.kind != '+' {
+				oldStart++
+			}
+			if op.kind != '-' {
+				newStart++
+			}
+		}
+		for _, op := range ops[h[0]:h[1]] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, op := range ops[h[0]:h[1]] {
+			fmt.Fprintf(&b, "%c%s\n", op.kind, op.text)
+		}
+	}
+	return b.String()
+}
+
+type diffOp struct {
+	kind byte // ' ', '-' or '+'
+	text string
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// lineOps computes a line-level edit script from a to b using a longest
+// common subsequence table.
+func lineOps(a, b []string) []diffOp {
+	var ops []diffOp
+	n, m := len(a), len(b)
+	if n*m > maxDiffCells {
+		for _, l := range a {
+			ops = append(ops, diffOp{'-', l})
+		}
+		for _, l := range b {
+			ops = append(ops, diffOp{'+', l})
+		}
+		return ops
+	}
+
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		ops = append(ops, diffOp{'-', a[i]})
+	}
+	for ; j < m; j++ {
+		ops = append(ops, diffOp{'+', b[j]})
+	}
+	return ops
+}
+
+// hunks groups changed ops with surrounding context into [start, end) ranges.
+func hunks(ops []diffOp) [][2]int {
+	var result [][2]int
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+		start := max(0, i-diffContext)
+		end := i + 1
+		// Extend while the next change is within two context windows
+		for k := i + 1; k < len(ops); k++ {
+			if ops[k].kind == ' ' {
+				if k-end >= 2*diffContext {
+					break
+				}
+				continue
+			}
+			end = k + 1
+		}
+		end = min(len(ops), end+diffContext)
+		if n := len(result); n > 0 && start <= result[n-1][1] {
+			result[n-1][1] = end
+		} else {
+			result = append(result, [2]int{start, end})
+		}
+		i = end - 1
+	}
+	return result
+}
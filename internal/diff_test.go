@@ -0,0 +1,72 @@
+package internal
+
+import "testing"
+
+func TestChangedFiles(t *testing.T) {
+	old := map[string]string{"SKILL.md": "a\n", "gone.md": "x\n", "run.sh": "echo 1\n"}
+	new := map[string]string{"SKILL.md": "a\n", "run.sh": "echo 2\n", "new.md": "y\n"}
+	changes := ChangedFiles(old, new)
+	want := []struct{ path, status string }{
+		{"gone.md", "removed"},
+		{"new.md", "added"},
+		{"run.sh", "modified"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("ChangedFiles() = %+v, want %d changes", changes, len(want))
+	}
+	for i, w := range want {
+		if changes[i].Path != w.path || changes[i].Status != w.status {
+			t.Errorf("changes[%d] = %s %s, want %s %s", i, changes[i].Path, changes[i].Status, w.path, w.status)
+		}
+	}
+}
+
+func TestUnified(t *testing.T) {
+	c := FileChange{
+		Path:   "SKILL.md",
+		Status: "modified",
+		Old:    "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+		New:    "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n",
+	}
+	want := `--- a/SKILL.md
++++ b/SKILL.md
+@@ -2,8 +2,9 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+ 9
++10
+`
+	if got := c.Unified(); got != want {
+		t.Errorf("Unified() =\n%s\nwant\n%s", got, want)
+	}
+	if ins, del := c.Stat(); ins != 2 || del != 1 {
+		t.Errorf("Stat() = +%d -%d, want +2 -1", ins, del)
+	}
+}
+
+func TestUnified_Added(t *testing.T) {
+	c := FileChange{Path: "new.md", Status: "added", New: "hello\n"}
+	want := "--- /dev/null\n+++ b/new.md\n@@ -0,0 +1,1 @@\n+hello\n"
+	if got := c.Unified(); got != want {
+		t.Errorf("Unified() = %q, want %q", got, want)
+	}
+}
+
+func TestUnified_Binary(t *testing.T) {
+	changes := ChangedFiles(map[string]string{"logo.png": "\x89PNG\x00a"}, map[string]string{"logo.png": "\x89PNG\x00b"})
+	if len(changes) != 1 || !changes[0].Binary {
+		t.Fatalf("ChangedFiles() = %+v, want one binary change", changes)
+	}
+	if got := changes[0].Unified(); got != "Binary file logo.png differs\n" {
+		t.Errorf("Unified() = %q", got)
+	}
+	if ins, del := changes[0].Stat(); ins != 0 || del != 0 {
+		t.Errorf("Stat() = +%d -%d, want +0 -0", ins, del)
+	}
+}
@@ -22,9 +22,28 @@ type Gist struct {
 	Owner       struct {
 		Login string `json:"login"`
 	} `json:"owner"`
-	History []struct {
-		Version string `json:"version"`
-	} `json:"history"`
+	History []GistRevision `json:"history"`
+
+	// Repository-backed providers set these: file names are then real
+	// slash-separated paths (no -- expansion), and Repo/RepoPath record
+	// where in the repository the skill came from.
+	PathNames bool   `json:"-"`
+	Repo      string `json:"-"`
+	RepoPath  string `json:"-"`
+}
+
+// GistRevision is a single entry in a gist's revision history.
+type GistRevision struct {
+	Version     string `json:"version"`
+	CommittedAt string `json:"committed_at"`
+}
+
+// Revision returns the commit SHA of the gist's current revision, if known.
+func (g *Gist) Revision() string {
+	if len(g.History) == 0 {
+		return ""
+	}
+	return g.History[0].Version
 }
 
 // ParseGistID extracts a gist ID from a URL or returns the input if already an ID.
@@ -36,3 +55,22 @@ func ParseGistID(input string) string {
 	}
 	return input
 }
+
+// ShortSHA abbreviates a commit SHA or other revision for display.
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// SplitRevision splits an "<id-or-url>@<revision>" reference into its parts.
+// The revision is empty when the input carries no "@" suffix.
+func SplitRevision(input string) (string, string) {
+	input = strings.TrimSpace(input)
+	i := strings.LastIndex(input, "@")
+	if i <= 0 || i == len(input)-1 || strings.Contains(input[i+1:], "/") {
+		return input, ""
+	}
+	return input[:i], input[i+1:]
+}
@@ -0,0 +1,24 @@
+package internal
+
+import "testing"
+
+func TestSplitRevision(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantRef string
+		wantRev string
+	}{
+		{"abc123", "abc123", ""},
+		{"abc123@deadbeef", "abc123", "deadbeef"},
+		{"https://gist.github.com/nico/abc123@deadbeef", "https://gist.github.com/nico/abc123", "deadbeef"},
+		{"https://gist.github.com/nico/abc123", "https://gist.github.com/nico/abc123", ""},
+		{"abc123@", "abc123@", ""},
+		{"@deadbeef", "@deadbeef", ""},
+	}
+	for _, tt := range tests {
+		ref, rev := SplitRevision(tt.input)
+		if ref != tt.wantRef || rev != tt.wantRev {
+			t.Errorf("SplitRevision(%q) = (%q, %q), want (%q, %q)", tt.input, ref, rev, tt.wantRef, tt.wantRev)
+		}
+	}
+}
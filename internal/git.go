@@ -0,0 +1,180 @@
+package internal
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitProvider implements Provider for plain git remotes using the git CLI.
+//
+// Snippet IDs have the form "<url>[//<path>][@<ref>]", e.g.
+// "https://git.example.com/team/skills.git//weather@v1". Without a path, the
+// directory containing SKILL.md is located automatically; without a ref, the
+// remote's HEAD is used.
+type GitProvider struct{}
+
+func (p *GitProvider) Name() string { return "git" }
+
+func (p *GitProvider) Host() string { return "" }
+
+// splitGitID splits "<url>[//<path>][@<ref>]" into its parts.
+func splitGitID(id string) (remote, dir, ref string) {
+	base, ref := SplitRevision(id)
+	scheme := ""
+	if i := strings.Index(base, "://"); i >= 0 {
+		scheme, base = base[:i+3], base[i+3:]
+	}
+	remote, dir, _ = strings.Cut(base, "//")
+	return scheme + remote, strings.Trim(dir, "/"), ref
+}
+
+// git runs a git command in dir and returns its trimmed stdout.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (p *GitProvider) FetchSnippet(id string) (*Gist, error) {
+	return p.fetch(id, "")
+}
+
+func (p *GitProvider) FetchSnippetRevision(id, revision string) (*Gist, error) {
+	return p.fetch(id, revision)
+}
+
+// LatestRevision resolves the commit SHA the ID's ref (or HEAD) currently
+// points at on the remote, without cloning it. A ref names a branch before a
+// tag, as in git; ls-remote patterns match any ref ending in the name, so
+// only these exact refs count.
+func (p *GitProvider) LatestRevision(id string) (string, error) {
+	remote, _, ref := splitGitID(id)
+	candidates := []string{"HEAD"}
+	switch {
+	case ref == "" || ref == "HEAD":
+	case strings.HasPrefix(ref, "refs/"):
+		candidates = []string{ref + "^{}", ref}
+	default:
+		candidates = []string{"refs/heads/" + ref, "refs/tags/" + ref + "^{}", "refs/tags/" + ref}
+	}
+	out, err := git("", append([]string{"ls-remote", remote}, candidates...)...)
+	if err != nil {
+		return "", err
+	}
+	refs := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		if fields := strings.Fields(line); len(fields) == 2 {
+			refs[fields[1]] = fields[0]
+		}
+	}
+	// An annotated tag's commit wins over the tag object
+	for _, name := range candidates {
+		if sha, ok := refs[name]; ok {
+			return sha, nil
+		}
+	}
+	// Not a branch or tag; a commit SHA resolves to itself.
+	return ref, nil
+}
+
+// fetch shallow-fetches a single commit into a temporary repository and reads
+// the skill directory's files from its work tree.
+func (p *GitProvider) fetch(id, revision string) (*Gist, error) {
+	remote, dir, ref := splitGitID(id)
+	if revision != "" {
+		ref = revision
+	}
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	tmp, err := os.MkdirTemp("", "gh-skill-git-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+
+	if _, err := git(tmp, "init", "--quiet"); err != nil {
+		return nil, err
+	}
+	if _, err := git(tmp, "fetch", "--quiet", "--depth", "1", remote, ref); err != nil {
+		return nil, fmt.Errorf("failed to fetch %s@%s: %w", remote, ref, err)
+	}
+	if _, err := git(tmp, "checkout", "--quiet", "FETCH_HEAD"); err != nil {
+		return nil, err
+	}
+	sha, err := git(tmp, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	err = filepath.WalkDir(tmp, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			rel, _ := filepath.Rel(tmp, path)
+			paths = append(paths, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	dir, files, err := locateSkillDir(paths, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", remote, err)
+	}
+
+	g := newRepoGist(id, remote, dir, sha)
+	g.HTMLURL = remote
+	for _, rel := range files {
+		data, err := os.ReadFile(filepath.Join(tmp, filepath.FromSlash(dir), filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, err
+		}
+		g.Files[rel] = GistFile{Filename: rel, Content: string(data)}
+	}
+	return g, nil
+}
+
+func (p *GitProvider) CreateSnippet(description string, files map[string]string, public bool) (*Gist, error) {
+	return nil, fmt.Errorf("publishing to a git remote is not supported; commit the skill folder with git instead")
+}
+
+func (p *GitProvider) UpdateSnippet(id, description string, files map[string]string) (*Gist, error) {
+	return nil, fmt.Errorf("updating a git remote is not supported; push the changes with git instead")
+}
+
+func (p *GitProvider) SearchSnippets(query string) ([]Gist, error) {
+	return nil, fmt.Errorf("searching is not supported for plain git remotes")
+}
+
+func (p *GitProvider) AuthenticatedUser() string { return "" }
+
+// isGitURL reports whether input is a git remote URL rather than a web URL:
+// scp-style git@host:path, ssh:// or git:// URLs, or anything ending in .git
+// (before an optional //<path> and @<ref>).
+func isGitURL(input string) bool {
+	if strings.HasPrefix(input, "git@") || strings.HasPrefix(input, "ssh://") || strings.HasPrefix(input, "git://") {
+		return true
+	}
+	remote, _, _ := splitGitID(input)
+	return strings.Contains(remote, "://") && strings.HasSuffix(remote, ".git")
+}
@@ -0,0 +1,347 @@
+package internal
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// GiteaProvider implements Provider for Gitea and Forgejo, which have no
+// gists: a skill is a repository, or a subdirectory of one, at a ref.
+//
+// Snippet IDs have the form "<owner>/<repo>[/<path>][@<ref>]"; without a ref
+// the repository's default branch is used.
+type GiteaProvider struct {
+	HostName string
+	Client   *GiteaClient
+}
+
+func (p *GiteaProvider) Name() string { return "gitea" }
+
+func (p *GiteaProvider) Host() string { return p.HostName }
+
+func (p *GiteaProvider) client() (*GiteaClient, error) {
+	if p.Client != nil {
+		return p.Client, nil
+	}
+	if p.HostName == "" {
+		return nil, fmt.Errorf("the gitea provider needs a host (configure gitea_hosts or pass --host)")
+	}
+	return NewGiteaClient(p.HostName), nil
+}
+
+// GiteaClient is a minimal Gitea/Forgejo REST API client.
+type GiteaClient struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewGiteaClient returns a client for https://<host>/api/v1 (or the host as
+// given if it already has a scheme), authenticated with GITEA_TOKEN or
+// FORGEJO_TOKEN.
+func NewGiteaClient(host string) *GiteaClient {
+	base := host
+	if !strings.Contains(base, "://") {
+		base = "https://" + base
+	}
+	token := os.Getenv("GITEA_TOKEN")
+	if token == "" {
+		token = os.Getenv("FORGEJO_TOKEN")
+	}
+	return &GiteaClient{BaseURL: strings.TrimRight(base, "/") + "/api/v1", Token: token}
+}
+
+func (c *GiteaClient) header() http.Header {
+	header := http.Header{}
+	header.Set("Accept", "application/json")
+	if c.Token != "" {
+		header.Set("Authorization", "token "+c.Token)
+	}
+	return header
+}
+
+func (c *GiteaClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return &http.Client{Timeout: 30 * time.Second}
+}
+
+// Do sends a JSON request to path (relative to BaseURL).
+func (c *GiteaClient) Do(method, path string, body, out interface{}) error {
+	return doJSON(c.httpClient(), method, c.BaseURL+path, c.header(), body, out)
+}
+
+// Raw fetches a non-JSON response body from path (relative to BaseURL).
+func (c *GiteaClient) Raw(path string) ([]byte, error) {
+	return doRequest(c.httpClient(), "GET", c.BaseURL+path, c.header(), nil)
+}
+
+// repoInfo is the repository JSON shape shared by the GitHub and Gitea APIs.
+type repoInfo struct {
+	FullName      string `json:"full_name"`
+	Description   string `json:"description"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	Owner         struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+func (p *GiteaProvider) FetchSnippet(id string) (*Gist, error) {
+	return p.fetch(id, "")
+}
+
+func (p *GiteaProvider) FetchSnippetRevision(id, revision string) (*Gist, error) {
+	return p.fetch(id, revision)
+}
+
+// fetch reads the files of the skill directory at revision (or the ID's own
+// @ref, or the default branch) into a repository-backed Gist.
+func (p *GiteaProvider) fetch(id, revision string) (*Gist, error) {
+	c, err := p.client()
+	if err != nil {
+		return nil, err
+	}
+	repoName, dir, ref, err := splitRepoID(id)
+	if err != nil {
+		return nil, err
+	}
+	if revision != "" {
+		ref = revision
+	}
+
+	var repo repoInfo
+	if err := c.Do("GET", "/repos/"+repoName, nil, &repo); err != nil {
+		return nil, fmt.Errorf("failed to fetch repository %s: %w", repoName, err)
+	}
+	if ref == "" {
+		ref = repo.DefaultBranch
+	}
+
+	var commits []struct {
+		SHA string `json:"sha"`
+	}
+	if err := c.Do("GET", fmt.Sprintf("/repos/%s/commits?sha=%s&limit=1&stat=false", repoName, url.QueryEscape(ref)), nil, &commits); err != nil {
+		return nil, fmt.Errorf("failed to resolve %s@%s: %w", repoName, ref, err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("ref %q not found in %s", ref, repoName)
+	}
+	sha := commits[0].SHA
+
+	var tree struct {
+		Tree      []gitTreeEntry `json:"tree"`
+		Truncated bool           `json:"truncated"`
+	}
+	if err := c.Do("GET", fmt.Sprintf("/repos/%s/git/trees/%s?recursive=true&per_page=10000", repoName, sha), nil, &tree); err != nil {
+		return nil, fmt.Errorf("failed to list files in %s@%s: %w", repoName, ShortSHA(sha), err)
+	}
+	if tree.Truncated {
+		return nil, fmt.Errorf("repository %s is too large to install from", repoName)
+	}
+	var paths []string
+	for _, entry := range tree.Tree {
+		if entry.Type == "blob" {
+			paths = append(paths, entry.Path)
+		}
+	}
+	dir, files, err := locateSkillDir(paths, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", repoName, err)
+	}
+
+	g := newRepoGist(id, repoName, dir, sha)
+	g.Description = repo.Description
+	g.HTMLURL = repo.HTMLURL
+	g.CreatedAt = repo.CreatedAt
+	g.UpdatedAt = repo.UpdatedAt
+	g.Owner.Login = repo.Owner.Login
+
+	for _, rel := range files {
+		full := path.Join(dir, rel)
+		content, err := c.Raw(fmt.Sprintf("/repos/%s/raw/%s?ref=%s", repoName, escapePath(full), sha))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", full, err)
+		}
+		g.Files[rel] = GistFile{Filename: rel, Content: string(content)}
+	}
+	return g, nil
+}
+
+// CreateSnippet creates a new repository named after the skill and commits
+// the files to it, expanding -- names back into directories.
+func (p *GiteaProvider) CreateSnippet(description string, files map[string]string, public bool) (*Gist, error) {
+	c, err := p.client()
+	if err != nil {
+		return nil, err
+	}
+	name := ""
+	for filename := range files {
+		if n := SkillNameFromFile(filename); n != "" && !strings.Contains(filename, "--") {
+			name = n
+			break
+		}
+	}
+	if name == "" {
+		name = fmt.Sprintf("gh-skill-%d", time.Now().Unix())
+	}
+
+	// Repositories hold real paths and bytes: expand -- names, decode
+	// base64-encoded binary files and name the top-level skill file SKILL.md
+	type fileOp struct {
+		Operation string `json:"operation"`
+		Path      string `json:"path"`
+		Content   string `json:"content"`
+	}
+	var ops []fileOp
+	for filename, content := range files {
+		if IsBinaryName(filename) {
+			decoded, err := DecodeBinary(content)
+			if err != nil {
+				return nil, fmt.Errorf("%s is not valid base64: %w", filename, err)
+			}
+			filename, content = strings.TrimSuffix(filename, BinarySuffix), decoded
+		}
+		rel := strings.ReplaceAll(filename, "--", "/")
+		if IsSkillFile(rel) && !strings.Contains(rel, "/") {
+			rel = "SKILL.md"
+		}
+		ops = append(ops, fileOp{
+			Operation: "create",
+			Path:      rel,
+			Content:   base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	}
+
+	var repo repoInfo
+	payload := map[string]interface{}{
+		"name":        name,
+		"description": description,
+		"private":     !public,
+	}
+	if err := c.Do("POST", "/user/repos", payload, &repo); err != nil {
+		return nil, fmt.Errorf("failed to create repository %s: %w", name, err)
+	}
+
+	var result struct {
+		Commit struct {
+			SHA string `json:"sha"`
+		} `json:"commit"`
+	}
+	if err := c.Do("POST", "/repos/"+repo.FullName+"/contents", map[string]interface{}{
+		"message": "Publish skill " + name,
+		"files":   ops,
+	}, &result); err != nil {
+		return nil, fmt.Errorf("failed to commit files to %s: %w", repo.FullName, err)
+	}
+
+	g := repo.toGist()
+	g.History = []GistRevision{{Version: result.Commit.SHA}}
+	return g, nil
+}
+
+func (p *GiteaProvider) UpdateSnippet(id, description string, files map[string]string) (*Gist, error) {
+	return nil, fmt.Errorf("updating a published repository is not supported; push the changes with git instead")
+}
+
+func (r *repoInfo) toGist() *Gist {
+	g := &Gist{
+		ID:          r.FullName,
+		Description: r.Description,
+		HTMLURL:     r.HTMLURL,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	g.Owner.Login = r.Owner.Login
+	return g
+}
+
+func (p *GiteaProvider) SearchSnippets(query string) ([]Gist, error) {
+	c, err := p.client()
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data []repoInfo `json:"data"`
+	}
+	if err := c.Do("GET", "/repos/search?limit=50&q="+url.QueryEscape(query), nil, &resp); err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+	var results []Gist
+	for _, r := range resp.Data {
+		if !strings.Contains(strings.ToLower(r.Description), "[gh-skill]") {
+			continue
+		}
+		results = append(results, *r.toGist())
+	}
+	return results, nil
+}
+
+func (p *GiteaProvider) AuthenticatedUser() string {
+	c, err := p.client()
+	if err != nil || c.Token == "" {
+		return ""
+	}
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := c.Do("GET", "/user", nil, &user); err != nil {
+		return ""
+	}
+	return user.Login
+}
+
+// parseGiteaURL matches repository URLs on configured Gitea/Forgejo hosts:
+// https://<host>/<owner>/<repo>[/src/{branch,tag,commit}/<ref>[/<path>]].
+// It returns the snippet ID "<owner>/<repo>[/<path>][@<ref>]".
+func parseGiteaURL(input string, hosts []string) (string, string, bool) {
+	hostPart, urlPath, ok := splitURL(input)
+	if !ok {
+		return "", "", false
+	}
+	found := ""
+	for _, h := range hosts {
+		trimmed := strings.TrimRight(h, "/")
+		bare := trimmed
+		if i := strings.Index(bare, "://"); i >= 0 {
+			bare = bare[i+3:]
+		}
+		if strings.EqualFold(bare, hostPart) {
+			found = trimmed
+			break
+		}
+	}
+	if found == "" {
+		return "", "", false
+	}
+	segs := strings.Split(urlPath, "/")
+	if len(segs) < 2 {
+		return "", "", false
+	}
+	id := segs[0] + "/" + strings.TrimSuffix(segs[1], ".git")
+	if len(segs) >= 5 && segs[2] == "src" {
+		if len(segs) > 5 {
+			id += "/" + strings.Join(segs[5:], "/")
+		}
+		id += "@" + segs[4]
+	}
+	return found, id, true
+}
+
+// escapePath escapes each segment of a slash-separated path.
+func escapePath(p string) string {
+	segs := strings.Split(p, "/")
+	for i, s := range segs {
+		segs[i] = url.PathEscape(s)
+	}
+	return strings.Join(segs, "/")
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// giteaStub serves a repository "team/skills" whose default branch "main"
+// points at commit "c0ffee" and contains a skill under weather/.
+func giteaStub(t *testing.T) *GiteaProvider {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/repos/team/skills", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"full_name":"team/skills","description":"[gh-skill] team skills","html_url":"https://forge.example/team/skills","default_branch":"main","owner":{"login":"team"}}`))
+	})
+	mux.HandleFunc("/api/v1/repos/team/skills/commits", func(w http.ResponseWriter, r *http.Request) {
+		if sha := r.URL.Query().Get("sha"); sha != "main" && sha != "c0ffee" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[{"sha":"c0ffee"}]`))
+	})
+	mux.HandleFunc("/api/v1/repos/team/skills/git/trees/c0ffee", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tree":[
+			{"path":"README.md","type":"blob"},
+			{"path":"weather","type":"tree"},
+			{"path":"weather/SKILL.md","type":"blob"},
+			{"path":"weather/scripts/fetch.sh","type":"blob"},
+			{"path":"weather/.env","type":"blob"}
+		]}`))
+	})
+	mux.HandleFunc("/api/v1/repos/team/skills/raw/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "c0ffee" {
+			t.Errorf("raw ref = %q, want c0ffee", r.URL.Query().Get("ref"))
+		}
+		w.Write([]byte("content of " + r.URL.Path[len("/api/v1/repos/team/skills/raw/"):]))
+	})
+	mux.HandleFunc("/api/v1/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "weather" || body["private"] != true {
+			t.Errorf("create repo body = %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"full_name":"me/weather","html_url":"https://forge.example/me/weather","owner":{"login":"me"}}`))
+	})
+	mux.HandleFunc("/api/v1/repos/me/weather/contents", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Files []struct {
+				Path    string `json:"path"`
+				Content string `json:"content"`
+			} `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		paths := map[string]string{}
+		for _, f := range body.Files {
+			data, _ := base64.StdEncoding.DecodeString(f.Content)
+			paths[f.Path] = string(data)
+		}
+		if paths["SKILL.md"] != "skill" || paths["scripts/fetch.sh"] != "echo" || paths["logo.png"] != "\x89PNG\x00" || paths["docs/old.skill.md"] != "notes" {
+			t.Errorf("committed files = %v", paths)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"commit":{"sha":"beef01"}}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return &GiteaProvider{HostName: "forge.example", Client: &GiteaClient{BaseURL: srv.URL + "/api/v1"}}
+}
+
+func TestGiteaProvider_FetchSnippet(t *testing.T) {
+	p := giteaStub(t)
+	g, err := p.FetchSnippet("team/skills/weather")
+	if err != nil {
+		t.Fatalf("FetchSnippet() error: %v", err)
+	}
+	if g.Revision() != "c0ffee" || g.Owner.Login != "team" {
+		t.Errorf("FetchSnippet() = %+v", g)
+	}
+	if len(g.Files) != 2 {
+		t.Fatalf("Files = %v, want SKILL.md and scripts/fetch.sh", g.Files)
+	}
+	if got := g.Files["scripts/fetch.sh"].Content; got != "content of weather/scripts/fetch.sh" {
+		t.Errorf("scripts/fetch.sh = %q", got)
+	}
+	if !g.PathNames || g.Repo != "team/skills" || g.RepoPath != "weather" {
+		t.Errorf("repository fields = %v %q %q", g.PathNames, g.Repo, g.RepoPath)
+	}
+	if _, ok := g.Files["SKILL.md"]; !ok {
+		t.Errorf("SKILL.md missing from %v", g.Files)
+	}
+
+	if _, err := p.FetchSnippetRevision("team/skills/weather", "nope"); err == nil {
+		t.Error("FetchSnippetRevision(unknown ref) = nil error")
+	}
+}
+
+func TestGiteaProvider_CreateSnippet(t *testing.T) {
+	p := giteaStub(t)
+	g, err := p.CreateSnippet("[gh-skill] weather", map[string]string{
+		"weather.skill.md":        "skill",
+		"scripts--fetch.sh":       "echo",
+		"logo.png" + BinarySuffix: EncodeBinary([]byte("\x89PNG\x00")),
+		"docs--old.skill.md":      "notes",
+	}, false)
+	if err != nil {
+		t.Fatalf("CreateSnippet() error: %v", err)
+	}
+	if g.ID != "me/weather" || g.Revision() != "beef01" {
+		t.Errorf("CreateSnippet() = %+v", g)
+	}
+}
+
+func TestParseGiteaURL(t *testing.T) {
+	hosts := []string{"forge.example"}
+	tests := []struct {
+		input  string
+		wantID string
+		wantOK bool
+	}{
+		{"https://forge.example/team/skills", "team/skills", true},
+		{"https://forge.example/team/skills.git", "team/skills", true},
+		{"https://forge.example/team/skills/src/branch/main/weather", "team/skills/weather@main", true},
+		{"https://forge.example/team/skills/src/commit/c0ffee", "team/skills@c0ffee", true},
+		{"https://other.example/team/skills", "", false},
+	}
+	for _, tt := range tests {
+		host, id, ok := parseGiteaURL(tt.input, hosts)
+		if ok != tt.wantOK || id != tt.wantID || (ok && host != "forge.example") {
+			t.Errorf("parseGiteaURL(%q) = (%q, %q, %v), want (forge.example, %q, %v)", tt.input, host, id, ok, tt.wantID, tt.wantOK)
+		}
+	}
+}
@@ -1,52 +1,119 @@
 package internal
 
 import (
-	"encoding/json"
 	"fmt"
-	"os/exec"
+	"net/url"
 	"strings"
 )
 
-// GitHubProvider implements Provider using gh CLI for GitHub Gists.
-type GitHubProvider struct{}
+// GitHubProvider implements Provider for GitHub Gists using the REST API.
+// HostName selects a GitHub Enterprise Server host ("" for github.com).
+// A nil Client uses NewGitHubClientForHost(HostName).
+type GitHubProvider struct {
+	HostName string
+	Client   *GitHubClient
+}
 
 func (p *GitHubProvider) Name() string { return "github" }
 
+func (p *GitHubProvider) Host() string { return p.HostName }
+
+func (p *GitHubProvider) client() *GitHubClient {
+	if p.Client != nil {
+		return p.Client
+	}
+	return NewGitHubClientForHost(p.HostName)
+}
+
 func (p *GitHubProvider) FetchSnippet(id string) (*Gist, error) {
-	return FetchGist(id)
+	return p.client().FetchGist(id)
+}
+
+func (p *GitHubProvider) FetchSnippetRevision(id, revision string) (*Gist, error) {
+	return p.client().FetchGistRevision(id, revision)
 }
 
 func (p *GitHubProvider) CreateSnippet(description string, files map[string]string, public bool) (*Gist, error) {
-	return CreateGist(description, files, public)
+	return p.client().CreateGist(description, files, public)
+}
+
+func (p *GitHubProvider) UpdateSnippet(id, description string, files map[string]string) (*Gist, error) {
+	c := p.client()
+	current, err := c.FetchGist(id)
+	if err != nil {
+		return nil, err
+	}
+	var deleted []string
+	for name := range current.Files {
+		if _, ok := files[name]; !ok {
+			deleted = append(deleted, name)
+		}
+	}
+	return c.UpdateGist(id, description, files, deleted)
 }
 
 func (p *GitHubProvider) SearchSnippets(query string) ([]Gist, error) {
-	return SearchGists(query)
+	return p.client().SearchGists(query)
 }
 
 func (p *GitHubProvider) AuthenticatedUser() string {
-	out, err := exec.Command("gh", "api", "user", "--jq", ".login").Output()
-	if err != nil {
-		return ""
-	}
-	return strings.TrimSpace(string(out))
+	return p.client().AuthenticatedUser()
 }
 
-// FetchGist fetches a gist by ID using the gh CLI.
+// FetchGist fetches a gist by ID.
 func FetchGist(gistID string) (*Gist, error) {
-	out, err := exec.Command("gh", "api", fmt.Sprintf("/gists/%s", gistID)).Output()
-	if err != nil {
+	return NewGitHubClient().FetchGist(gistID)
+}
+
+// FetchGistRevision fetches a gist as it was at the given revision SHA.
+func FetchGistRevision(gistID, revision string) (*Gist, error) {
+	return NewGitHubClient().FetchGistRevision(gistID, revision)
+}
+
+// CreateGist creates a new gist.
+func CreateGist(description string, files map[string]string, public bool) (*Gist, error) {
+	return NewGitHubClient().CreateGist(description, files, public)
+}
+
+// SearchGists searches for skills by combining the user's own gists with
+// a GitHub code search for repo-hosted skills.
+func SearchGists(query string) ([]Gist, error) {
+	return NewGitHubClient().SearchGists(query)
+}
+
+// FetchGist fetches a gist by ID.
+func (c *GitHubClient) FetchGist(gistID string) (*Gist, error) {
+	var g Gist
+	if err := c.Do("GET", "/gists/"+url.PathEscape(gistID), nil, &g); err != nil {
 		return nil, fmt.Errorf("failed to fetch gist %s: %w", gistID, err)
 	}
+	return &g, nil
+}
+
+// FetchGistRevision fetches a gist as it was at the given revision SHA.
+func (c *GitHubClient) FetchGistRevision(gistID, revision string) (*Gist, error) {
 	var g Gist
-	if err := json.Unmarshal(out, &g); err != nil {
-		return nil, fmt.Errorf("failed to parse gist response: %w", err)
+	if err := c.Do("GET", fmt.Sprintf("/gists/%s/%s", url.PathEscape(gistID), url.PathEscape(revision)), nil, &g); err != nil {
+		return nil, fmt.Errorf("failed to fetch gist %s at revision %s: %w", gistID, revision, err)
 	}
+	pinRevision(&g, revision)
 	return &g, nil
 }
 
-// CreateGist creates a new gist using the gh CLI.
-func CreateGist(description string, files map[string]string, public bool) (*Gist, error) {
+// pinRevision moves the history entry matching revision (which may be an
+// abbreviated SHA) to the front so Revision() reports the fetched version.
+func pinRevision(g *Gist, revision string) {
+	for i, h := range g.History {
+		if strings.HasPrefix(h.Version, revision) {
+			g.History[0], g.History[i] = g.History[i], g.History[0]
+			return
+		}
+	}
+	g.History = append([]GistRevision{{Version: revision}}, g.History...)
+}
+
+// CreateGist creates a new gist.
+func (c *GitHubClient) CreateGist(description string, files map[string]string, public bool) (*Gist, error) {
 	gistFiles := make(map[string]map[string]string)
 	for name, content := range files {
 		gistFiles[name] = map[string]string{"content": content}
@@ -56,45 +123,72 @@ func CreateGist(description string, files map[string]string, public bool) (*Gist
 		"public":      public,
 		"files":       gistFiles,
 	}
-	data, _ := json.Marshal(payload)
-	cmd := exec.Command("gh", "api", "/gists", "--method", "POST", "--input", "-")
-	cmd.Stdin = strings.NewReader(string(data))
-	out, err := cmd.Output()
-	if err != nil {
+	var g Gist
+	if err := c.Do("POST", "/gists", payload, &g); err != nil {
 		return nil, fmt.Errorf("failed to create gist: %w", err)
 	}
+	return &g, nil
+}
+
+// UpdateGist sets the description of an existing gist, adds or replaces the
+// given files and deletes the deleted ones. Other files are left as they are.
+func (c *GitHubClient) UpdateGist(gistID, description string, files map[string]string, deleted []string) (*Gist, error) {
+	gistFiles := make(map[string]interface{})
+	for name, content := range files {
+		gistFiles[name] = map[string]string{"content": content}
+	}
+	for _, name := range deleted {
+		gistFiles[name] = nil
+	}
+	payload := map[string]interface{}{
+		"description": description,
+		"files":       gistFiles,
+	}
 	var g Gist
-	if err := json.Unmarshal(out, &g); err != nil {
-		return nil, fmt.Errorf("failed to parse gist response: %w", err)
+	if err := c.Do("PATCH", "/gists/"+gistID, payload, &g); err != nil {
+		return nil, fmt.Errorf("failed to update gist %s: %w", gistID, err)
 	}
 	return &g, nil
 }
 
+// AuthenticatedUser returns the login of the token's user, or "" if the
+// client is unauthenticated.
+func (c *GitHubClient) AuthenticatedUser() string {
+	if c.Token == "" {
+		return ""
+	}
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := c.Do("GET", "/user", nil, &user); err != nil {
+		return ""
+	}
+	return user.Login
+}
+
 // SearchGists searches for skills by combining the user's own gists with
 // a GitHub code search for repo-hosted skills.
-func SearchGists(query string) ([]Gist, error) {
+func (c *GitHubClient) SearchGists(query string) ([]Gist, error) {
 	seen := make(map[string]bool)
 	var results []Gist
 
 	// 1. User's own gists — GET /gists?per_page=100
-	if out, err := exec.Command("gh", "api", "/gists?per_page=100").Output(); err == nil {
-		var gists []Gist
-		if err := json.Unmarshal(out, &gists); err == nil {
-			for _, g := range gists {
-				if seen[g.ID] {
-					continue
-				}
-				desc := strings.ToLower(g.Description)
-				if !strings.Contains(desc, "[gh-skill]") {
-					continue
-				}
-				if !gistHasSkillFile(g) {
-					continue
-				}
-				if query == "" || strings.Contains(desc, strings.ToLower(query)) {
-					seen[g.ID] = true
-					results = append(results, g)
-				}
+	var gists []Gist
+	if err := c.Do("GET", "/gists?per_page=100", nil, &gists); err == nil {
+		for _, g := range gists {
+			if seen[g.ID] {
+				continue
+			}
+			desc := strings.ToLower(g.Description)
+			if !strings.Contains(desc, "[gh-skill]") {
+				continue
+			}
+			if !gistHasSkillFile(g) {
+				continue
+			}
+			if query == "" || strings.Contains(desc, strings.ToLower(query)) {
+				seen[g.ID] = true
+				results = append(results, g)
 			}
 		}
 	}
@@ -102,30 +196,30 @@ func SearchGists(query string) ([]Gist, error) {
 	// 2. Code search for repo-hosted skills
 	if query != "" {
 		q := fmt.Sprintf("%s gh-skill filename:skill.md", query)
-		endpoint := fmt.Sprintf("/search/code?q=%s&per_page=30", strings.ReplaceAll(q, " ", "+"))
-		if out, err := exec.Command("gh", "api", endpoint).Output(); err == nil {
-			var searchResp codeSearchResponse
-			if err := json.Unmarshal(out, &searchResp); err == nil {
-				for _, item := range searchResp.Items {
-					repoFullName := item.Repository.FullName
-					if seen[repoFullName] {
-						continue
-					}
-					seen[repoFullName] = true
-					// Convert code search hit to a Gist-like result
-					results = append(results, Gist{
-						ID:          repoFullName,
-						Description: item.Repository.Description,
-						HTMLURL:     item.Repository.HTMLURL,
-						Files: map[string]GistFile{
-							item.Name: {Filename: item.Name, RawURL: item.HTMLURL},
-						},
-						Owner: struct {
-							Login string `json:"login"`
-						}{Login: item.Repository.Owner.Login},
-					})
+		endpoint := fmt.Sprintf("/search/code?q=%s&per_page=30", url.QueryEscape(q))
+		var searchResp codeSearchResponse
+		if err := c.Do("GET", endpoint, nil, &searchResp); err == nil {
+			for _, item := range searchResp.Items {
+				repoFullName := item.Repository.FullName
+				if seen[repoFullName] {
+					continue
 				}
+				seen[repoFullName] = true
+				// Convert code search hit to a Gist-like result
+				results = append(results, Gist{
+					ID:          repoFullName,
+					Description: item.Repository.Description,
+					HTMLURL:     item.Repository.HTMLURL,
+					Files: map[string]GistFile{
+						item.Name: {Filename: item.Name, RawURL: item.HTMLURL},
+					},
+					Owner: struct {
+						Login string `json:"login"`
+					}{Login: item.Repository.Owner.Login},
+				})
 			}
+		} else if len(results) == 0 {
+			return nil, fmt.Errorf("search failed: %w", err)
 		}
 	}
 
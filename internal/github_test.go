@@ -0,0 +1,169 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestGitHubClient(t *testing.T, handler http.HandlerFunc) *GitHubClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &GitHubClient{BaseURL: srv.URL, Token: "test-token"}
+}
+
+func TestGitHubClient_FetchGist(t *testing.T) {
+	c := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gists/abc123" {
+			t.Errorf("path = %q, want /gists/abc123", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		w.Write([]byte(`{"id":"abc123","owner":{"login":"nico"},"files":{"demo.skill.md":{"filename":"demo.skill.md","content":"hi"}},"history":[{"version":"sha1"}]}`))
+	})
+	p := &GitHubProvider{Client: c}
+	g, err := p.FetchSnippet("abc123")
+	if err != nil {
+		t.Fatalf("FetchSnippet() error: %v", err)
+	}
+	if g.Owner.Login != "nico" || g.Revision() != "sha1" || g.Files["demo.skill.md"].Content != "hi" {
+		t.Errorf("FetchSnippet() = %+v", g)
+	}
+}
+
+func TestGitHubClient_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		headers map[string]string
+		want    error
+	}{
+		{"not found", http.StatusNotFound, nil, ErrNotFound},
+		{"unauthorized", http.StatusUnauthorized, nil, ErrUnauthorized},
+		{"rate limited", http.StatusForbidden, map[string]string{"X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700000000"}, ErrRateLimited},
+		{"too many requests", http.StatusTooManyRequests, nil, ErrRateLimited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"message":"nope"}`))
+			})
+			_, err := c.FetchGist("abc123")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("FetchGist() error = %v, want %v", err, tt.want)
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("FetchGist() error = %#v, want *APIError with status %d", err, tt.status)
+			}
+		})
+	}
+}
+
+func TestGitHubClient_CreateGist(t *testing.T) {
+	c := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/gists" {
+			t.Errorf("request = %s %s, want POST /gists", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Description string                       `json:"description"`
+			Public      bool                         `json:"public"`
+			Files       map[string]map[string]string `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Description != "[gh-skill] demo" || body.Public || body.Files["demo.skill.md"]["content"] != "hi" {
+			t.Errorf("body = %+v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"new1","html_url":"https://gist.github.com/nico/new1"}`))
+	})
+	g, err := c.CreateGist("[gh-skill] demo", map[string]string{"demo.skill.md": "hi"}, false)
+	if err != nil {
+		t.Fatalf("CreateGist() error: %v", err)
+	}
+	if g.ID != "new1" {
+		t.Errorf("CreateGist() ID = %q, want new1", g.ID)
+	}
+}
+
+func TestGitHubClient_UpdateGist(t *testing.T) {
+	c := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PATCH" || r.URL.Path != "/gists/abc123" {
+			t.Errorf("request = %s %s, want PATCH /gists/abc123", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Description string                       `json:"description"`
+			Files       map[string]map[string]string `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body.Description != "[gh-skill] pack" || body.Files["gistskills.json"]["content"] != "{}" {
+			t.Errorf("body = %+v", body)
+		}
+		if f, ok := body.Files["old.md"]; !ok || f != nil {
+			t.Errorf("old.md = %v, want null to delete it", f)
+		}
+		w.Write([]byte(`{"id":"abc123","html_url":"https://gist.github.com/nico/abc123","history":[{"version":"sha2"}]}`))
+	})
+	g, err := c.UpdateGist("abc123", "[gh-skill] pack", map[string]string{"gistskills.json": "{}"}, []string{"old.md"})
+	if err != nil {
+		t.Fatalf("UpdateGist() error: %v", err)
+	}
+	if g.ID != "abc123" || g.Revision() != "sha2" {
+		t.Errorf("UpdateGist() = %+v", g)
+	}
+}
+
+func TestGitHubProvider_UpdateSnippetDeletesMissingFiles(t *testing.T) {
+	c := newTestGitHubClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`{"id":"abc123","files":{"demo.skill.md":{"filename":"demo.skill.md"},"old.md":{"filename":"old.md"}}}`))
+			return
+		}
+		var body struct {
+			Files map[string]*struct {
+				Content string `json:"content"`
+			} `json:"files"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if f, ok := body.Files["old.md"]; !ok || f != nil {
+			t.Errorf("old.md = %v, want null", f)
+		}
+		if f := body.Files["demo.skill.md"]; f == nil || f.Content != "v2" {
+			t.Errorf("demo.skill.md = %v", f)
+		}
+		if f := body.Files["new.md"]; f == nil || f.Content != "new" {
+			t.Errorf("new.md = %v", f)
+		}
+		w.Write([]byte(`{"id":"abc123","history":[{"version":"sha2"}]}`))
+	})
+	p := &GitHubProvider{Client: c}
+	g, err := p.UpdateSnippet("abc123", "[gh-skill] demo", map[string]string{"demo.skill.md": "v2", "new.md": "new"})
+	if err != nil {
+		t.Fatalf("UpdateSnippet() error: %v", err)
+	}
+	if g.Revision() != "sha2" {
+		t.Errorf("Revision() = %q, want sha2", g.Revision())
+	}
+}
+
+func TestPinRevision(t *testing.T) {
+	g := &Gist{History: []GistRevision{{Version: "aaa111"}, {Version: "bbb222"}}}
+	pinRevision(g, "bbb")
+	if got := g.Revision(); got != "bbb222" {
+		t.Errorf("Revision() = %q, want bbb222", got)
+	}
+
+	g2 := &Gist{}
+	pinRevision(g2, "ccc333")
+	if got := g2.Revision(); got != "ccc333" {
+		t.Errorf("Revision() = %q, want ccc333", got)
+	}
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultGitHubAPIURL is the REST API base URL for github.com.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubClient is a minimal GitHub REST API client.
+type GitHubClient struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewGitHubClient returns a client for the configured API base URL
+// (github_api_url in config.json, default api.github.com), authenticated with
+// GH_TOKEN, GITHUB_TOKEN or `gh auth token`.
+func NewGitHubClient() *GitHubClient {
+	return NewGitHubClientForHost("")
+}
+
+// NewGitHubClientForHost returns a client for a GitHub Enterprise Server host,
+// whose API lives at https://<host>/api/v3. An empty host means github.com.
+func NewGitHubClientForHost(host string) *GitHubClient {
+	baseURL := DefaultGitHubAPIURL
+	if host != "" {
+		baseURL = "https://" + host + "/api/v3"
+	} else if cfg, err := LoadConfig(); err == nil && cfg.GitHubAPIURL != "" {
+		baseURL = cfg.GitHubAPIURL
+	}
+	return &GitHubClient{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Token:   githubToken(host),
+	}
+}
+
+var (
+	ghTokenMu    sync.Mutex
+	ghTokenCache = map[string]string{}
+)
+
+// githubToken resolves an API token for host ("" for github.com), preferring
+// the environment and falling back to the gh CLI if it is installed.
+func githubToken(host string) string {
+	envs := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != "" {
+		envs = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, env := range envs {
+		if t := os.Getenv(env); t != "" {
+			return t
+		}
+	}
+
+	ghTokenMu.Lock()
+	defer ghTokenMu.Unlock()
+	if t, ok := ghTokenCache[host]; ok {
+		return t
+	}
+	args := []string{"auth", "token"}
+	if host != "" {
+		args = append(args, "--hostname", host)
+	}
+	out, err := exec.Command("gh", args...).Output()
+	token := ""
+	if err == nil {
+		token = strings.TrimSpace(string(out))
+	}
+	ghTokenCache[host] = token
+	return token
+}
+
+func (c *GitHubClient) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return &http.Client{Timeout: 30 * time.Second}
+}
+
+// Do sends a request to path (relative to BaseURL), encoding body as JSON if
+// non-nil and decoding the response into out if non-nil.
+func (c *GitHubClient) Do(method, path string, body, out interface{}) error {
+	header := http.Header{}
+	header.Set("Accept", "application/vnd.github+json")
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.Token != "" {
+		header.Set("Authorization", "Bearer "+c.Token)
+	}
+	return doJSON(c.httpClient(), method, c.BaseURL+path, header, body, out)
+}
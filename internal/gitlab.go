@@ -3,15 +3,39 @@ package internal
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os/exec"
 	"strings"
 )
 
 // GitLabProvider implements Provider using glab CLI for GitLab Snippets.
-type GitLabProvider struct{}
+// HostName selects a self-hosted GitLab instance ("" for gitlab.com).
+type GitLabProvider struct {
+	HostName string
+}
 
 func (p *GitLabProvider) Name() string { return "gitlab" }
 
+func (p *GitLabProvider) Host() string { return p.HostName }
+
+// glab builds a `glab api` command against the provider's host.
+func (p *GitLabProvider) glab(endpoint string, args ...string) *exec.Cmd {
+	full := []string{"api", endpoint}
+	if p.HostName != "" {
+		full = append(full, "--hostname", p.HostName)
+	}
+	return exec.Command("glab", append(full, args...)...)
+}
+
+// snippetEndpoint returns the API path for a snippet ID. Personal snippets
+// are plain numeric IDs; project snippets are "<group>/<project>/<id>".
+func snippetEndpoint(id string) string {
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		return fmt.Sprintf("/projects/%s/snippets/%s", url.PathEscape(id[:i]), id[i+1:])
+	}
+	return "/snippets/" + id
+}
+
 // gitlabSnippet is the JSON shape returned by the GitLab snippets API.
 type gitlabSnippet struct {
 	ID          int    `json:"id"`
@@ -53,7 +77,23 @@ func (s *gitlabSnippet) toGist() *Gist {
 }
 
 func (p *GitLabProvider) FetchSnippet(id string) (*Gist, error) {
-	out, err := exec.Command("glab", "api", fmt.Sprintf("/snippets/%s", id)).Output()
+	return p.fetchSnippetAt(id, "main")
+}
+
+// FetchSnippetRevision fetches a snippet with file contents read at the given
+// repository ref (GitLab snippets are backed by a git repository).
+func (p *GitLabProvider) FetchSnippetRevision(id, revision string) (*Gist, error) {
+	g, err := p.fetchSnippetAt(id, revision)
+	if err != nil {
+		return nil, err
+	}
+	g.History = []GistRevision{{Version: revision}}
+	return g, nil
+}
+
+func (p *GitLabProvider) fetchSnippetAt(id, ref string) (*Gist, error) {
+	endpoint := snippetEndpoint(id)
+	out, err := p.glab(endpoint).Output()
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch snippet %s: %w", id, err)
 	}
@@ -62,12 +102,14 @@ func (p *GitLabProvider) FetchSnippet(id string) (*Gist, error) {
 		return nil, fmt.Errorf("failed to parse snippet response: %w", err)
 	}
 	g := s.toGist()
+	g.ID = id // keep the project path of project snippets
 
-	// Fetch raw content for each file
+	// Fetch raw content for each file, byte for byte: binary files pushed
+	// to the snippet's repository must not be mangled or dropped
 	for _, f := range s.Files {
-		rawOut, err := exec.Command("glab", "api", fmt.Sprintf("/snippets/%s/files/main/%s/raw", id, f.Path)).Output()
+		rawOut, err := p.glab(fmt.Sprintf("%s/files/%s/%s/raw", endpoint, url.PathEscape(ref), url.PathEscape(f.Path))).Output()
 		if err != nil {
-			continue
+			return nil, fmt.Errorf("failed to fetch %s from snippet %s: %w", f.Path, id, err)
 		}
 		gf := g.Files[f.Path]
 		gf.Content = string(rawOut)
@@ -100,7 +142,7 @@ func (p *GitLabProvider) CreateSnippet(description string, files map[string]stri
 		"files":       sf,
 	}
 	data, _ := json.Marshal(payload)
-	cmd := exec.Command("glab", "api", "/snippets", "--method", "POST", "--input", "-")
+	cmd := p.glab("/snippets", "--method", "POST", "--input", "-")
 	cmd.Stdin = strings.NewReader(string(data))
 	out, err := cmd.Output()
 	if err != nil {
@@ -113,9 +155,64 @@ func (p *GitLabProvider) CreateSnippet(description string, files map[string]stri
 	return s.toGist(), nil
 }
 
+// UpdateSnippet replaces the snippet's files using GitLab's per-file
+// create/update/delete actions.
+func (p *GitLabProvider) UpdateSnippet(id, description string, files map[string]string) (*Gist, error) {
+	endpoint := snippetEndpoint(id)
+	out, err := p.glab(endpoint).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch snippet %s: %w", id, err)
+	}
+	var current gitlabSnippet
+	if err := json.Unmarshal(out, &current); err != nil {
+		return nil, fmt.Errorf("failed to parse snippet response: %w", err)
+	}
+
+	type fileAction struct {
+		Action   string `json:"action"`
+		FilePath string `json:"file_path"`
+		Content  string `json:"content,omitempty"`
+	}
+	existing := make(map[string]bool)
+	var actions []fileAction
+	for _, f := range current.Files {
+		existing[f.Path] = true
+		if _, ok := files[f.Path]; !ok {
+			actions = append(actions, fileAction{Action: "delete", FilePath: f.Path})
+		}
+	}
+	for name, content := range files {
+		action := "create"
+		if existing[name] {
+			action = "update"
+		}
+		actions = append(actions, fileAction{Action: action, FilePath: name, Content: content})
+	}
+
+	payload := map[string]interface{}{
+		"title":       description,
+		"description": description,
+		"files":       actions,
+	}
+	data, _ := json.Marshal(payload)
+	cmd := p.glab(endpoint, "--method", "PUT", "--input", "-")
+	cmd.Stdin = strings.NewReader(string(data))
+	out, err = cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to update snippet %s: %w", id, err)
+	}
+	var s gitlabSnippet
+	if err := json.Unmarshal(out, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse snippet response: %w", err)
+	}
+	g := s.toGist()
+	g.ID = id
+	return g, nil
+}
+
 func (p *GitLabProvider) SearchSnippets(query string) ([]Gist, error) {
 	encodedQuery := strings.ReplaceAll(query, " ", "+")
-	out, err := exec.Command("glab", "api", fmt.Sprintf("/snippets/public?per_page=100&search=%s", encodedQuery)).Output()
+	out, err := p.glab(fmt.Sprintf("/snippets/public?per_page=100&search=%s", encodedQuery)).Output()
 	if err != nil {
 		return nil, fmt.Errorf("search failed: %w", err)
 	}
@@ -141,7 +238,7 @@ func (p *GitLabProvider) SearchSnippets(query string) ([]Gist, error) {
 }
 
 func (p *GitLabProvider) AuthenticatedUser() string {
-	out, err := exec.Command("glab", "api", "/user", "--jq", ".username").Output()
+	out, err := p.glab("/user", "--jq", ".username").Output()
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const historyDir = ".history"
+
+// SkillRevision is a previously installed revision kept under
+// ~/.gistskills/.history/<name>/<sha>/.
+type SkillRevision struct {
+	Meta    SkillMeta
+	Path    string
+	SavedAt time.Time
+}
+
+// snapshotMeta is the metadata of a saved revision: the skill's own plus
+// when it was replaced.
+type snapshotMeta struct {
+	SkillMeta
+	SavedAt string `json:"saved_at,omitempty"`
+}
+
+func skillHistoryPath(name string) string {
+	return filepath.Join(SkillsBasePath(), historyDir, name)
+}
+
+// SnapshotSkill copies the currently installed revision of a skill into its
+// history, then prunes history down to the configured retention count.
+// It is a no-op if the skill is not installed or history is disabled.
+func SnapshotSkill(name string) error {
+	current, err := GetSkill(name)
+	if err != nil {
+		return nil
+	}
+	cfg, err := LoadConfig()
+	if err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+	keep := cfg.EffectiveHistoryRetention()
+	if keep == 0 {
+		return nil
+	}
+
+	id := current.CommitSHA
+	if id == "" {
+		id = "unknown-" + time.Now().UTC().Format("20060102T150405Z")
+	}
+	dest := filepath.Join(skillHistoryPath(name), id)
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	if err := os.CopyFS(dest, os.DirFS(filepath.Join(SkillsBasePath(), name))); err != nil {
+		return fmt.Errorf("failed to save revision %s of %q: %w", id, name, err)
+	}
+	// Record when the revision was replaced; copying does not keep mtimes
+	data, err := json.MarshalIndent(snapshotMeta{*current, time.Now().UTC().Format(time.RFC3339Nano)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dest, ".gistskill.json"), data, 0644); err != nil {
+		return err
+	}
+	return pruneHistory(name, keep)
+}
+
+// SkillHistory lists the saved revisions of a skill, newest first.
+func SkillHistory(name string) ([]SkillRevision, error) {
+	base := skillHistoryPath(name)
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var revs []SkillRevision
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		path := filepath.Join(base, e.Name())
+		data, err := os.ReadFile(filepath.Join(path, ".gistskill.json"))
+		if err != nil {
+			continue
+		}
+		var meta snapshotMeta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			continue
+		}
+		savedAt, err := time.Parse(time.RFC3339Nano, meta.SavedAt)
+		if err != nil {
+			// Saved before snapshots recorded the time
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			savedAt = info.ModTime()
+		}
+		revs = append(revs, SkillRevision{Meta: meta.SkillMeta, Path: path, SavedAt: savedAt})
+	}
+	sort.Slice(revs, func(i, j int) bool { return revs[i].SavedAt.After(revs[j].SavedAt) })
+	return revs, nil
+}
+
+func pruneHistory(name string, keep int) error {
+	revs, err := SkillHistory(name)
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(revs); i++ {
+		if err := os.RemoveAll(revs[i].Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RollbackSkill restores a saved revision of a skill. An empty sha selects
+// the most recent saved revision that differs from the installed one. The
+// revision being replaced is itself saved to history, and the restored skill
+// is pinned so `update --all` does not immediately undo the rollback.
+func RollbackSkill(name, sha string) (*SkillMeta, error) {
+	current, err := GetSkill(name)
+	if err != nil {
+		return nil, err
+	}
+	revs, err := SkillHistory(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *SkillRevision
+	for i := range revs {
+		rev := &revs[i]
+		if sha == "" && rev.Meta.CommitSHA != current.CommitSHA {
+			target = rev
+			break
+		}
+		if sha != "" && strings.HasPrefix(rev.Meta.CommitSHA, sha) {
+			target = rev
+			break
+		}
+	}
+	if target == nil {
+		if sha == "" {
+			return nil, fmt.Errorf("no previous revision of %q in history", name)
+		}
+		return nil, fmt.Errorf("revision %s of %q not found in history", sha, name)
+	}
+
+	// Copy the target aside first: snapshotting the current revision may prune it.
+	staged, err := os.MkdirTemp(SkillsBasePath(), "."+name+".staging-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staged)
+	restore := filepath.Join(staged, name)
+	if err := os.CopyFS(restore, os.DirFS(target.Path)); err != nil {
+		return nil, fmt.Errorf("failed to read revision %s: %w", target.Meta.CommitSHA, err)
+	}
+
+	if err := SnapshotSkill(name); err != nil {
+		return nil, err
+	}
+
+	if err := swapSkillDir(restore, filepath.Join(SkillsBasePath(), name)); err != nil {
+		return nil, fmt.Errorf("failed to restore revision %s: %w", target.Meta.CommitSHA, err)
+	}
+
+	meta := target.Meta
+	meta.Pinned = true
+	meta.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	// As with InstallSkill, membership and rendered files belong to the skill,
+	// not to the revision
+	meta.Collections = current.Collections
+	meta.Renders = current.Renders
+	if err := SaveSkillMeta(&meta); err != nil {
+		return nil, err
+	}
+	// Best effort, like InstallSkill: the rollback itself is done either way
+	RefreshNativeRenders(&meta)
+	return &meta, nil
+}
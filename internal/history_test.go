@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testGist(sha, body string) *Gist {
+	return &Gist{
+		ID:      "abc123",
+		Files:   map[string]GistFile{"demo.skill.md": {Content: "---\nname: demo\n---\n" + body}},
+		History: []GistRevision{{Version: sha}},
+	}
+}
+
+func TestRollbackSkill(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := InstallSkill(testGist("aaa111", "first")); err != nil {
+		t.Fatalf("InstallSkill(first) error: %v", err)
+	}
+	if _, err := InstallSkill(testGist("bbb222", "second")); err != nil {
+		t.Fatalf("InstallSkill(second) error: %v", err)
+	}
+
+	revs, err := SkillHistory("demo")
+	if err != nil {
+		t.Fatalf("SkillHistory() error: %v", err)
+	}
+	if len(revs) != 1 || revs[0].Meta.CommitSHA != "aaa111" {
+		t.Fatalf("SkillHistory() = %+v, want [aaa111]", revs)
+	}
+
+	meta, err := RollbackSkill("demo", "")
+	if err != nil {
+		t.Fatalf("RollbackSkill() error: %v", err)
+	}
+	if meta.CommitSHA != "aaa111" || !meta.Pinned {
+		t.Errorf("RollbackSkill() meta = %+v, want pinned aaa111", meta)
+	}
+	content, _ := os.ReadFile(filepath.Join(SkillsBasePath(), "demo", "SKILL.md"))
+	if want := "---\nname: demo\n---\nfirst"; string(content) != want {
+		t.Errorf("SKILL.md = %q, want %q", content, want)
+	}
+
+	// The replaced revision is kept so the rollback can be undone
+	if _, err := RollbackSkill("demo", "bbb"); err != nil {
+		t.Errorf("RollbackSkill(bbb) error: %v", err)
+	}
+}
+
+func TestHistoryRetention(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	os.MkdirAll(SkillsBasePath(), 0755)
+	os.WriteFile(filepath.Join(SkillsBasePath(), "config.json"), []byte(`{"history_retention": 1}`), 0644)
+
+	for _, sha := range []string{"aaa111", "bbb222", "ccc333"} {
+		if _, err := InstallSkill(testGist(sha, sha)); err != nil {
+			t.Fatalf("InstallSkill(%s) error: %v", sha, err)
+		}
+	}
+	revs, _ := SkillHistory("demo")
+	if len(revs) != 1 || revs[0].Meta.CommitSHA != "bbb222" {
+		t.Errorf("SkillHistory() = %+v, want only bbb222", revs)
+	}
+}
+
+func TestSkillHistory_OrdersBySaveTime(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for _, sha := range []string{"aaa111", "bbb222", "ccc333"} {
+		if _, err := InstallSkill(testGist(sha, sha)); err != nil {
+			t.Fatalf("InstallSkill(%s) error: %v", sha, err)
+		}
+	}
+	// Directory mtimes change when files are touched; they must not matter
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(skillHistoryPath("demo"), "bbb222"), old, old)
+
+	revs, _ := SkillHistory("demo")
+	if len(revs) != 2 || revs[0].Meta.CommitSHA != "bbb222" || revs[1].Meta.CommitSHA != "aaa111" {
+		t.Errorf("SkillHistory() = %+v, want [bbb222 aaa111]", revs)
+	}
+}
+
+func TestRollbackSkill_KeepsRendersAndCollections(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+
+	if _, err := InstallSkill(testGist("aaa111", "# Demo\n\nfirst\n")); err != nil {
+		t.Fatalf("InstallSkill(first) error: %v", err)
+	}
+	if _, err := InstallSkill(testGist("bbb222", "# Demo\n\nsecond\n")); err != nil {
+		t.Fatalf("InstallSkill(second) error: %v", err)
+	}
+	cursor, _ := NativeFormatByName("cursor-rules")
+	mdc, err := RenderNativeSkill("demo", cursor, root)
+	if err != nil {
+		t.Fatalf("RenderNativeSkill() error: %v", err)
+	}
+	meta, _ := GetSkill("demo")
+	meta.Collections.Add("Pack")
+	SaveSkillMeta(meta)
+
+	rolled, err := RollbackSkill("demo", "aaa")
+	if err != nil {
+		t.Fatalf("RollbackSkill() error: %v", err)
+	}
+	if len(rolled.Renders) != 1 || !rolled.Collections.Has("Pack") {
+		t.Errorf("rolled back meta = %+v, want the render and collection kept", rolled)
+	}
+	data, _ := os.ReadFile(mdc)
+	if !strings.Contains(string(data), "first") {
+		t.Errorf("demo.mdc = %s, want the rolled back content", data)
+	}
+}
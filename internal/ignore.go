@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SkillIgnoreFile lists files publish and fork leave out of a skill, in
+// .gitignore syntax.
+const SkillIgnoreFile = ".skillignore"
+
+// IgnoreRules is a parsed set of gitignore-style patterns.
+type IgnoreRules struct {
+	rules []ignoreRule
+}
+
+type ignoreRule struct {
+	re      *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// ParseIgnore parses gitignore-syntax patterns. Blank lines and # comments
+// are skipped; !, trailing / and ** behave as they do in .gitignore.
+func ParseIgnore(content string) *IgnoreRules {
+	r := &IgnoreRules{}
+	r.Add(content)
+	return r
+}
+
+// LoadIgnoreFile reads and parses the ignore file at path. A missing file
+// yields no rules.
+func LoadIgnoreFile(path string) (*IgnoreRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &IgnoreRules{}, nil
+		}
+		return nil, err
+	}
+	return ParseIgnore(string(data)), nil
+}
+
+// Add appends the patterns in content; later patterns take precedence.
+func (r *IgnoreRules) Add(content string) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var rule ignoreRule
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		} else if strings.HasPrefix(line, `\`) {
+			line = line[1:] // escaped leading ! or #
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+		// A slash anywhere but the end anchors the pattern to the root
+		anchored := strings.Contains(line, "/")
+		line = strings.TrimPrefix(line, "/")
+		expr := globToRegexp(line)
+		if !anchored {
+			expr = "(?:.*/)?" + expr
+		}
+		re, err := regexp.Compile("^" + expr + "$")
+		if err != nil {
+			continue // malformed character class; git ignores these too
+		}
+		rule.re = re
+		r.rules = append(r.rules, rule)
+	}
+}
+
+// Match reports whether the slash-separated path rel (relative to the skill
+// directory) is ignored. The last matching pattern wins.
+func (r *IgnoreRules) Match(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range r.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.re.MatchString(rel) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// globToRegexp translates one gitignore glob into a regular expression.
+func globToRegexp(glob string) string {
+	var b strings.Builder
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "/**") && i+3 == len(glob):
+			b.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(glob):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(glob[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// SkillIgnoreRules returns the ignore rules for the skill folder dir: its
+// .skillignore and, if withGitignore is set, its .gitignore.
+func SkillIgnoreRules(dir string, withGitignore bool) (*IgnoreRules, error) {
+	rules := &IgnoreRules{}
+	names := []string{SkillIgnoreFile}
+	if withGitignore {
+		names = []string{".gitignore", SkillIgnoreFile}
+	}
+	for _, name := range names {
+		r, err := LoadIgnoreFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		rules.rules = append(rules.rules, r.rules...)
+	}
+	return rules, nil
+}
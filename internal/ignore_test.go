@@ -0,0 +1,39 @@
+package internal
+
+import "testing"
+
+func TestIgnoreRules_Match(t *testing.T) {
+	rules := ParseIgnore(`# build output
+node_modules/
+*.log
+!keep.log
+/dist
+docs/**/*.pdf
+secret?.txt
+`)
+	tests := []struct {
+		path  string
+		isDir bool
+		want  bool
+	}{
+		{"node_modules", true, true},
+		{"scripts/node_modules", true, true},
+		{"node_modules", false, false},
+		{"debug.log", false, true},
+		{"logs/debug.log", false, true},
+		{"keep.log", false, false},
+		{"dist", true, true},
+		{"scripts/dist", true, false},
+		{"docs/guide.pdf", false, true},
+		{"docs/a/b/guide.pdf", false, true},
+		{"guide.pdf", false, false},
+		{"secret1.txt", false, true},
+		{"secret10.txt", false, false},
+		{"SKILL.md", false, false},
+	}
+	for _, tt := range tests {
+		if got := rules.Match(tt.path, tt.isDir); got != tt.want {
+			t.Errorf("Match(%q, %v) = %v, want %v", tt.path, tt.isDir, got, tt.want)
+		}
+	}
+}
@@ -15,8 +15,12 @@ type ToolTarget struct {
 }
 
 // DetectToolDirs returns paths to skill directories for detected AI tools.
-// For OpenClaw, only the main agent's skills directory is included.
+// For OpenClaw, only the main agent's skills directory is included. In the
+// project scope these are the project's tool directories.
 func DetectToolDirs() []string {
+	if projectRoot != "" {
+		return ProjectToolDirs(projectRoot)
+	}
 	home, _ := os.UserHomeDir()
 	var dirs []string
 
@@ -48,6 +52,27 @@ func DetectToolDirs() []string {
 	return dirs
 }
 
+// projectTools are the tools with project-level skill directories, with
+// their config directory relative to the project root.
+var projectTools = []ToolTarget{
+	{"claude-code", ".claude"},
+	{"cursor", ".cursor"},
+	{"codex", ".codex"},
+	{"opencode", ".opencode"},
+}
+
+// ProjectToolDirs returns project-level skill directories under root for
+// tools whose config directory exists there (e.g. <root>/.claude/skills).
+func ProjectToolDirs(root string) []string {
+	var dirs []string
+	for _, t := range projectTools {
+		if _, err := os.Stat(filepath.Join(root, t.Dir)); err == nil {
+			dirs = append(dirs, filepath.Join(root, t.Dir, "skills"))
+		}
+	}
+	return dirs
+}
+
 // KnownTools returns all known tool targets including all OpenClaw agents.
 func KnownTools() []ToolTarget {
 	home, _ := os.UserHomeDir()
@@ -163,6 +188,9 @@ func openclawAgentTargets(home string) []ToolTarget {
 
 // LinkSkill creates a symlink for a skill in the given tool directory.
 func LinkSkill(skillName, toolDir string) error {
+	if err := ValidateSkillName(skillName); err != nil {
+		return err
+	}
 	skillDir := filepath.Join(SkillsBasePath(), skillName)
 	if _, err := os.Stat(skillDir); os.IsNotExist(err) {
 		return fmt.Errorf("skill %q not found", skillName)
@@ -175,12 +203,49 @@ func LinkSkill(skillName, toolDir string) error {
 	linkPath := filepath.Join(toolDir, skillName)
 	os.Remove(linkPath)
 
-	if err := os.Symlink(skillDir, linkPath); err != nil {
+	// Project links are relative so they survive the repository being
+	// cloned or moved elsewhere
+	target := skillDir
+	if projectRoot != "" {
+		if rel, err := filepath.Rel(toolDir, skillDir); err == nil {
+			target = rel
+		}
+	}
+	if err := os.Symlink(target, linkPath); err != nil {
 		return fmt.Errorf("failed to create symlink: %w", err)
 	}
 	return nil
 }
 
+// isManagedLink reports whether path is a symlink into the managed skills
+// directory, resolving relative links from the directory they are in.
+func isManagedLink(path string) bool {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return false
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	return strings.HasPrefix(filepath.Clean(target), SkillsBasePath()+string(filepath.Separator))
+}
+
+// ManagedLinks returns the names of entries in toolDir that are symlinks
+// into the managed skills directory.
+func ManagedLinks(toolDir string) []string {
+	entries, err := os.ReadDir(toolDir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if isManagedLink(filepath.Join(toolDir, e.Name())) {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
 // AutoLink links a skill to all detected tool directories.
 // For OpenClaw, only the main agent is linked.
 func AutoLink(skillName string) []string {
@@ -195,7 +260,18 @@ func AutoLink(skillName string) []string {
 
 // ToolDirByName returns the skill directory for a named tool.
 // "openclaw" resolves to the main agent. "openclaw/<agent>" targets a specific agent.
+// In the project scope only tools with project-level directories resolve.
 func ToolDirByName(name string) (string, error) {
+	if projectRoot != "" {
+		var names []string
+		for _, t := range projectTools {
+			if t.Name == name {
+				return filepath.Join(projectRoot, t.Dir, "skills"), nil
+			}
+			names = append(names, t.Name)
+		}
+		return "", fmt.Errorf("tool %q has no project-level skill directory (project scope: %s)", name, strings.Join(names, ", "))
+	}
 	home, _ := os.UserHomeDir()
 
 	// Handle bare "openclaw" → main agent
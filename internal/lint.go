@@ -0,0 +1,327 @@
+package internal
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Lint issue severities. Errors make a skill fail lint; warnings don't.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// Limits from the skill spec.
+const (
+	maxSkillNameLength   = 64
+	maxDescriptionLength = 1024
+	minDescriptionLength = 20
+)
+
+// LintIssue is a single problem found in a skill folder.
+type LintIssue struct {
+	Severity string `json:"severity"`
+	Rule     string `json:"rule"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (i LintIssue) String() string {
+	loc := i.File
+	if i.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", i.File, i.Line)
+	}
+	if loc != "" {
+		loc += ": "
+	}
+	return fmt.Sprintf("%s: %s%s [%s]", i.Severity, loc, i.Message, i.Rule)
+}
+
+// LintReport is the result of linting one skill folder.
+type LintReport struct {
+	Path   string      `json:"path"`
+	Skill  string      `json:"skill,omitempty"`
+	Issues []LintIssue `json:"issues"`
+}
+
+// Errors returns the number of error-level issues.
+func (r *LintReport) Errors() int {
+	n := 0
+	for _, i := range r.Issues {
+		if i.Severity == LintError {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *LintReport) add(severity, rule, file string, line int, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, LintIssue{
+		Severity: severity,
+		Rule:     rule,
+		File:     file,
+		Line:     line,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+var (
+	skillNamePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	markdownLink     = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	uriScheme        = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+)
+
+// frontMatterKeys are the front matter fields the skill spec defines.
+var frontMatterKeys = map[string]bool{
+	"name": true, "description": true, "version": true, "tags": true, "tools": true, "author": true,
+	"license": true, "allowed-tools": true, "metadata": true, "compatibility": true,
+}
+
+// LintSkill checks the skill folder dir against the skill spec: front matter
+// fields, name format, description length, semver version, tool names,
+// relative links between the skill's files, gist size limits and filename
+// collisions after flattening. Files are considered as publish would upload
+// them, honoring .skillignore (and .gitignore if withGitignore is set).
+func LintSkill(dir string, withGitignore bool) (*LintReport, error) {
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+	r := &LintReport{Path: dir}
+
+	content, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		r.add(LintError, "skill-file", "SKILL.md", 0, "SKILL.md is missing")
+		return r, nil
+	}
+	name := lintFrontMatter(r, dir, string(content))
+	r.Skill = name
+	if name == "" {
+		name = filepath.Base(dir)
+	}
+
+	// Gather the files as they would be published
+	sizes := make(map[string]int64)
+	texts := make(map[string]string)
+	var names []string
+	err = walkSkillFiles(dir, withGitignore, func(rel, p string, fi os.FileInfo) error {
+		sizes[rel] = fi.Size()
+		names = append(names, rel)
+		if fi.Size() > MaxGistFileSize {
+			r.add(LintError, "file-size", rel, 0, "%s is larger than the %s gist limit", FormatSize(fi.Size()), FormatSize(MaxGistFileSize))
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if IsBinary(data) {
+			if n := len(EncodeBinary(data)); n > MaxGistFileSize {
+				r.add(LintError, "file-size", rel, 0, "%s once base64-encoded is larger than the %s gist limit", FormatSize(int64(n)), FormatSize(MaxGistFileSize))
+			}
+			sizes[rel] = -1 // binary: no links to check
+			return nil
+		}
+		texts[rel] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	if len(names) > MaxGistFiles {
+		r.add(LintError, "file-count", "", 0, "%d files is more than the %d a gist can hold", len(names), MaxGistFiles)
+	}
+
+	// Distinct paths must stay distinct, and in place, through the gist
+	published := make(map[string]string)
+	for _, rel := range names {
+		gistName := publishName(rel, name, sizes[rel] < 0)
+		if other, ok := published[gistName]; ok {
+			r.add(LintError, "filename-collision", rel, 0, "publishes as %s, the same gist file as %s", gistName, other)
+			continue
+		}
+		published[gistName] = rel
+		if strings.Contains(path.Base(rel), "--") {
+			r.add(LintWarning, "filename-collision", rel, 0, "-- in the file name is installed as a directory separator (%s)", ExpandFilename(FlattenFilename(rel)))
+		}
+	}
+
+	for _, rel := range names {
+		if strings.HasSuffix(strings.ToLower(rel), ".md") {
+			lintLinks(r, rel, texts[rel], sizes)
+		}
+	}
+	return r, nil
+}
+
+// lintFrontMatter checks SKILL.md's front matter and returns the skill name.
+func lintFrontMatter(r *LintReport, dir, content string) string {
+	lines := strings.Split(content, "\n")
+	if strings.TrimSpace(lines[0]) != "---" {
+		r.add(LintError, "front-matter", "SKILL.md", 1, "front matter is missing (SKILL.md must start with ---)")
+		return ""
+	}
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			end = i
+			break
+		}
+	}
+	if end < 0 {
+		r.add(LintError, "front-matter", "SKILL.md", 1, "front matter is not closed with ---")
+		return ""
+	}
+	lineOf := func(key string) int {
+		for i := 1; i < end; i++ {
+			if strings.HasPrefix(lines[i], key+":") {
+				return i + 1
+			}
+		}
+		return 1
+	}
+
+	var fields map[string]interface{}
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "\n")), &fields); err != nil {
+		r.add(LintError, "front-matter", "SKILL.md", 1, "invalid YAML: %v", err)
+		return ""
+	}
+	var keys []string
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !frontMatterKeys[k] {
+			r.add(LintWarning, "front-matter", "SKILL.md", lineOf(k), "unknown front matter field %q", k)
+		}
+	}
+	fm, err := ParseFrontMatter(content)
+	if err != nil {
+		r.add(LintError, "front-matter", "SKILL.md", 1, "%v", err)
+		return ""
+	}
+
+	if fm.Name == "" {
+		r.add(LintError, "name", "SKILL.md", 1, "name is required")
+	} else if err := CheckSkillName(fm.Name); err != nil {
+		r.add(LintError, "name", "SKILL.md", lineOf("name"), "%v", err)
+	}
+	if base := filepath.Base(dir); fm.Name != "" && fm.Name != base {
+		r.add(LintWarning, "name", "SKILL.md", lineOf("name"), "name %q does not match the directory name %q", fm.Name, base)
+	}
+
+	desc := strings.TrimSpace(fm.Description)
+	switch {
+	case desc == "":
+		r.add(LintError, "description", "SKILL.md", 1, "description is required")
+	case len(desc) > maxDescriptionLength:
+		r.add(LintError, "description", "SKILL.md", lineOf("description"), "description is %d characters, more than %d", len(desc), maxDescriptionLength)
+	case len(desc) < minDescriptionLength:
+		r.add(LintWarning, "description", "SKILL.md", lineOf("description"), "description is very short; say what the skill does and when to use it")
+	}
+
+	if fm.Version != "" {
+		if _, err := ParseVersion(fm.Version); err != nil {
+			r.add(LintError, "version", "SKILL.md", lineOf("version"), "%v", err)
+		}
+	}
+
+	for _, tool := range fm.Tools {
+		if !isKnownTool(tool) {
+			r.add(LintWarning, "tools", "SKILL.md", lineOf("tools"), "unknown tool %q (known: %s)", tool, strings.Join(knownToolNames(), ", "))
+		}
+	}
+	return fm.Name
+}
+
+// CheckSkillName reports whether name follows the skill spec: at most 64
+// lowercase letters, digits and single hyphens.
+func CheckSkillName(name string) error {
+	switch {
+	case len(name) > maxSkillNameLength:
+		return fmt.Errorf("name is longer than %d characters", maxSkillNameLength)
+	case !skillNamePattern.MatchString(name):
+		return fmt.Errorf("name %q must be lowercase letters, digits and single hyphens", name)
+	}
+	return nil
+}
+
+// knownToolNames lists the tool names KnownTools links to, with every
+// OpenClaw agent folded into "openclaw".
+func knownToolNames() []string {
+	seen := map[string]bool{"openclaw": true}
+	names := []string{"openclaw"}
+	for _, t := range KnownTools() {
+		n, _, _ := strings.Cut(t.Name, "/")
+		if !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func isKnownTool(name string) bool {
+	base, _, _ := strings.Cut(name, "/")
+	for _, n := range knownToolNames() {
+		if n == base {
+			return true
+		}
+	}
+	return false
+}
+
+// lintLinks reports relative Markdown links in file rel that point outside
+// the skill or at files that are not published.
+func lintLinks(r *LintReport, rel, content string, files map[string]int64) {
+	for i, line := range strings.Split(content, "\n") {
+		for _, m := range markdownLink.FindAllStringSubmatch(line, -1) {
+			target := m[1]
+			if strings.HasPrefix(target, "#") || uriScheme.MatchString(target) || strings.HasPrefix(target, "/") {
+				continue
+			}
+			if j := strings.IndexAny(target, "#?"); j >= 0 {
+				target = target[:j]
+			}
+			if unescaped, err := url.PathUnescape(target); err == nil {
+				target = unescaped
+			}
+			resolved := path.Clean(path.Join(path.Dir(rel), target))
+			if resolved == ".." || strings.HasPrefix(resolved, "../") {
+				r.add(LintError, "broken-link", rel, i+1, "link to %s points outside the skill", m[1])
+				continue
+			}
+			if _, ok := files[resolved]; ok {
+				continue
+			}
+			if isPublishedDir(resolved, files) {
+				continue
+			}
+			r.add(LintError, "broken-link", rel, i+1, "link to %s: no such file in the skill", m[1])
+		}
+	}
+}
+
+func isPublishedDir(dir string, files map[string]int64) bool {
+	if dir == "." {
+		return true
+	}
+	for f := range files {
+		if strings.HasPrefix(f, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
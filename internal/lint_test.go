@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func lintRules(r *LintReport) string {
+	var rules []string
+	for _, i := range r.Issues {
+		rules = append(rules, i.Severity+":"+i.Rule)
+	}
+	return strings.Join(rules, ",")
+}
+
+func TestLintSkill_Clean(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "weather")
+	writeTestFiles(t, dir, map[string]string{
+		"SKILL.md":          "---\nname: weather\ndescription: Fetch forecasts when the user asks about the weather.\nversion: 1.2.0\ntools: [claude-code, openclaw/main]\n---\nSee [the API](references/api.md#usage) and [docs](https://example.com).\n",
+		"references/api.md": "Back to [the skill](../SKILL.md).\n",
+	})
+	r, err := LintSkill(dir, false)
+	if err != nil {
+		t.Fatalf("LintSkill() error: %v", err)
+	}
+	if len(r.Issues) != 0 {
+		t.Errorf("issues = %v, want none", r.Issues)
+	}
+}
+
+func TestLintSkill_Problems(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  string
+	}{
+		{"missing SKILL.md", map[string]string{"README.md": "hi"}, "error:skill-file"},
+		{"no front matter", map[string]string{"SKILL.md": "# Weather\n"}, "error:front-matter"},
+		{"unclosed front matter", map[string]string{"SKILL.md": "---\nname: weather\n"}, "error:front-matter"},
+		{"missing fields", map[string]string{"SKILL.md": "---\nauthor: nico\n---\n"}, "error:name,error:description"},
+		{"bad name", map[string]string{"SKILL.md": "---\nname: Weather_Bot\ndescription: Fetch forecasts for any city on request.\n---\n"}, "error:name,warning:name"},
+		{"unknown field", map[string]string{"SKILL.md": "---\nname: weather\ndescription: Fetch forecasts for any city on request.\ncolour: blue\n---\n"}, "warning:front-matter"},
+		{"bad version", map[string]string{"SKILL.md": "---\nname: weather\ndescription: Fetch forecasts for any city on request.\nversion: latest\n---\n"}, "error:version"},
+		{"unknown tool", map[string]string{"SKILL.md": "---\nname: weather\ndescription: Fetch forecasts for any city on request.\ntools: [vim]\n---\n"}, "warning:tools"},
+		{"long description", map[string]string{"SKILL.md": "---\nname: weather\ndescription: " + strings.Repeat("x", 1025) + "\n---\n"}, "error:description"},
+		{"broken links", map[string]string{
+			"SKILL.md":     "---\nname: weather\ndescription: Fetch forecasts for any city on request.\n---\n[a](missing.md) [b](../other/SKILL.md) [c](secret.md)\n",
+			".skillignore": "secret.md\n",
+			"secret.md":    "ignored",
+		}, "error:broken-link,error:broken-link,error:broken-link"},
+		{"collision", map[string]string{
+			"SKILL.md":         "---\nname: weather\ndescription: Fetch forecasts for any city on request.\n---\n",
+			"references--a.md": "flat",
+			"references/a.md":  "nested",
+		}, "warning:filename-collision,error:filename-collision"},
+		{"too large", map[string]string{
+			"SKILL.md": "---\nname: weather\ndescription: Fetch forecasts for any city on request.\n---\n",
+			"data.txt": strings.Repeat("x", MaxGistFileSize+1),
+		}, "error:file-size"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "weather")
+			writeTestFiles(t, dir, tt.files)
+			r, err := LintSkill(dir, false)
+			if err != nil {
+				t.Fatalf("LintSkill() error: %v", err)
+			}
+			if got := lintRules(r); got != tt.want {
+				t.Errorf("issues = %s, want %s\n%v", got, tt.want, r.Issues)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// maxSkillSize caps the total size of the files read from a local folder or
+// archive, so a stray path or a decompression bomb cannot exhaust memory.
+const maxSkillSize = 50 << 20
+
+// LocalProvider implements Provider for skill folders on the local
+// filesystem, for offline installs.
+//
+// Snippet IDs are absolute directory paths. Revisions are a digest of the
+// skill's files, so update reinstalls whenever the folder's content changes.
+type LocalProvider struct{}
+
+func (p *LocalProvider) Name() string { return "local" }
+
+func (p *LocalProvider) Host() string { return "" }
+
+func (p *LocalProvider) FetchSnippet(id string) (*Gist, error) {
+	info, err := os.Stat(id)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", id)
+	}
+
+	files := make(map[string]string)
+	total := 0
+	err = filepath.WalkDir(id, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !d.Type().IsRegular() {
+			return nil // directories, and symlinks that could point anywhere
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if total += len(data); total > maxSkillSize {
+			return fmt.Errorf("%s is larger than %d MB", id, maxSkillSize>>20)
+		}
+		rel, _ := filepath.Rel(id, p)
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := gistFromFiles(id, files)
+	if err != nil {
+		return nil, err
+	}
+	g.HTMLURL = "file://" + filepath.ToSlash(id)
+	return g, nil
+}
+
+func (p *LocalProvider) FetchSnippetRevision(id, revision string) (*Gist, error) {
+	g, err := p.FetchSnippet(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDigest(g, revision); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (p *LocalProvider) CreateSnippet(description string, files map[string]string, public bool) (*Gist, error) {
+	return nil, fmt.Errorf("publishing to a local folder is not supported")
+}
+
+func (p *LocalProvider) UpdateSnippet(id, description string, files map[string]string) (*Gist, error) {
+	return nil, fmt.Errorf("publishing to a local folder is not supported")
+}
+
+func (p *LocalProvider) SearchSnippets(query string) ([]Gist, error) {
+	return nil, fmt.Errorf("searching is not supported for local folders")
+}
+
+func (p *LocalProvider) AuthenticatedUser() string { return "" }
+
+// gistFromFiles maps the files of a folder or archive, keyed by slash-separated
+// path, into a Gist for the skill directory among them.
+func gistFromFiles(id string, files map[string]string) (*Gist, error) {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	dir, rels, err := locateSkillDir(paths, "")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", id, err)
+	}
+
+	g := newRepoGist(id, "", dir, "")
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+	for _, rel := range rels {
+		g.Files[rel] = GistFile{Filename: rel, Content: files[prefix+rel]}
+	}
+	_, isCollection := g.Files[CollectionFile]
+	if _, _, ok := FindSkillFile(g.Files); !ok && !isCollection {
+		return nil, fmt.Errorf("%s does not contain a SKILL.md or <name>.skill.md file", id)
+	}
+	g.History = []GistRevision{{Version: contentDigest(g.Files)}}
+	return g, nil
+}
+
+// contentDigest returns a SHA-256 over the names and contents of files,
+// standing in for a commit SHA where the source has no history.
+func contentDigest(files map[string]GistFile) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		sum := sha256.Sum256([]byte(files[name].Content))
+		fmt.Fprintf(h, "%s\x00%x\n", name, sum)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// checkDigest fails unless g's content still matches revision: sources
+// without history can only ever provide their current content.
+func checkDigest(g *Gist, revision string) error {
+	if !strings.HasPrefix(g.Revision(), revision) {
+		return fmt.Errorf("%s has changed since revision %s and keeps no history", g.ID, ShortSHA(revision))
+	}
+	return nil
+}
+
+// localPath reports whether input refers to the local filesystem (a
+// file:// URL, or a path starting with ".", "/" or "~") and returns it as an
+// absolute path.
+func localPath(input string) (string, bool) {
+	p, ok := strings.CutPrefix(input, "file://")
+	if !ok && !strings.HasPrefix(p, ".") && !strings.HasPrefix(p, "/") && !strings.HasPrefix(p, "~") {
+		return "", false
+	}
+	if rest, ok := strings.CutPrefix(p, "~"); ok && (rest == "" || rest[0] == '/') {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		p = home + rest
+	}
+	abs, err := filepath.Abs(filepath.FromSlash(p))
+	if err != nil {
+		return "", false
+	}
+	return abs, true
+}
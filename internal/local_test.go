@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSkillDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(p), 0755)
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLocalProvider_FetchSnippet(t *testing.T) {
+	dir := writeSkillDir(t, map[string]string{
+		"weather.skill.md":     "---\nname: weather\n---\n",
+		"scripts/fetch.sh":     "echo",
+		".git/HEAD":            "ref: refs/heads/main",
+		"references/.DS_Store": "junk",
+	})
+	p := &LocalProvider{}
+	g, err := p.FetchSnippet(dir)
+	if err != nil {
+		t.Fatalf("FetchSnippet() error: %v", err)
+	}
+	if len(g.Files) != 2 || g.Files["scripts/fetch.sh"].Content != "echo" {
+		t.Errorf("Files = %v, want weather.skill.md and scripts/fetch.sh", g.Files)
+	}
+	if name, _, ok := FindSkillFile(g.Files); !ok || name != "weather.skill.md" {
+		t.Errorf("FindSkillFile() = %q, %v", name, ok)
+	}
+
+	if _, err := p.FetchSnippetRevision(dir, g.Revision()[:7]); err != nil {
+		t.Errorf("FetchSnippetRevision(current) error: %v", err)
+	}
+	os.WriteFile(filepath.Join(dir, "scripts", "fetch.sh"), []byte("echo changed"), 0644)
+	changed, err := p.FetchSnippet(dir)
+	if err != nil {
+		t.Fatalf("FetchSnippet() error: %v", err)
+	}
+	if changed.Revision() == g.Revision() {
+		t.Error("Revision() did not change with the content")
+	}
+	if _, err := p.FetchSnippetRevision(dir, g.Revision()); err == nil {
+		t.Error("FetchSnippetRevision(stale) = nil error")
+	}
+}
+
+func TestLocalProvider_NoSkillFile(t *testing.T) {
+	dir := writeSkillDir(t, map[string]string{"README.md": "hi"})
+	if _, err := (&LocalProvider{}).FetchSnippet(dir); err == nil {
+		t.Error("FetchSnippet() = nil error, want missing SKILL.md")
+	}
+}
+
+func TestInstallSkill_Local(t *testing.T) {
+	dir := writeSkillDir(t, map[string]string{
+		"SKILL.md":         "---\nname: weather\n---\n",
+		"scripts/fetch.sh": "echo",
+	})
+	t.Setenv("HOME", t.TempDir())
+	g, err := (&LocalProvider{}).FetchSnippet(dir)
+	if err != nil {
+		t.Fatalf("FetchSnippet() error: %v", err)
+	}
+	meta, err := InstallSkill(g, &LocalProvider{})
+	if err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	if meta.Provider != "local" || meta.GistID != dir || meta.CommitSHA != g.Revision() {
+		t.Errorf("InstallSkill() meta = %+v", meta)
+	}
+	if _, err := os.Stat(filepath.Join(SkillsBasePath(), "weather", "scripts", "fetch.sh")); err != nil {
+		t.Errorf("scripts/fetch.sh not installed: %v", err)
+	}
+}
+
+func TestDetectProvider_Local(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	wd, _ := os.Getwd()
+
+	tests := []struct {
+		input        string
+		wantProvider string
+		wantID       string
+	}{
+		{"./skills/weather", "local", filepath.Join(wd, "skills", "weather")},
+		{"/opt/skills/weather", "local", "/opt/skills/weather"},
+		{"~/skills/weather", "local", filepath.Join(home, "skills", "weather")},
+		{"file:///opt/skills/weather", "local", "/opt/skills/weather"},
+		{"file:///opt/skills/weather.tar.gz", "archive", "/opt/skills/weather.tar.gz"},
+		{"weather.zip", "archive", filepath.Join(wd, "weather.zip")},
+		{"https://example.com/dl/weather.tgz?token=x", "archive", "https://example.com/dl/weather.tgz?token=x"},
+		{"https://github.com/team/skills/releases/download/v1/weather.zip", "archive", "https://github.com/team/skills/releases/download/v1/weather.zip"},
+	}
+	for _, tt := range tests {
+		provider, id := DetectProvider(tt.input)
+		if provider.Name() != tt.wantProvider || id != tt.wantID {
+			t.Errorf("DetectProvider(%q) = (%s, %q), want (%s, %q)", tt.input, provider.Name(), id, tt.wantProvider, tt.wantID)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package internal
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	ManifestFile = "skills.json"
+	LockFile     = "skills.lock"
+)
+
+// Manifest is the checked-in skills.json listing the skills a project needs.
+// Each entry is a gist URL or ID, optionally suffixed with @<sha>.
+type Manifest struct {
+	Skills []string `json:"skills"`
+}
+
+// LockEntry records the exact revision and file hashes a manifest entry resolved to.
+type LockEntry struct {
+	Source    string            `json:"source"`
+	Name      string            `json:"name"`
+	Provider  string            `json:"provider"`
+	Host      string            `json:"host,omitempty"`
+	GistID    string            `json:"gist_id"`
+	CommitSHA string            `json:"commit_sha"`
+	Files     map[string]string `json:"files"`
+}
+
+// Lockfile is the checked-in skills.lock generated by `gh skill sync`.
+type Lockfile struct {
+	Version int         `json:"version"`
+	Skills  []LockEntry `json:"skills"`
+}
+
+// FindProjectRoot walks up from dir to the nearest directory containing
+// skills.json or skills.lock.
+func FindProjectRoot(dir string) (string, error) {
+	if root, ok := findUp(dir, ManifestFile, LockFile); ok {
+		return root, nil
+	}
+	return "", fmt.Errorf("no %s found in this directory or any parent", ManifestFile)
+}
+
+// FindGitRoot walks up from dir to the nearest directory containing .git,
+// the root of the repository project-scoped skills are installed into.
+func FindGitRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	if root, ok := findUp(dir, ".git"); ok {
+		return root, nil
+	}
+	return "", fmt.Errorf("not inside a git repository")
+}
+
+// findUp walks up from dir to the nearest directory containing any of names.
+func findUp(dir string, names ...string) (string, bool) {
+	for {
+		for _, name := range names {
+			if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+				return dir, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// LoadManifest reads skills.json from a project root.
+func LoadManifest(root string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(root, ManifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Manifest{}, nil
+		}
+		return nil, err
+	}
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	return &m, nil
+}
+
+// LoadLockfile reads skills.lock from a project root. A missing lockfile is empty.
+func LoadLockfile(root string) (*Lockfile, error) {
+	data, err := os.ReadFile(filepath.Join(root, LockFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Lockfile{Version: 1}, nil
+		}
+		return nil, err
+	}
+	var l Lockfile
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", LockFile, err)
+	}
+	return &l, nil
+}
+
+// Save writes the lockfile to a project root.
+func (l *Lockfile) Save(root string) error {
+	if l.Version == 0 {
+		l.Version = 1
+	}
+	data, _ := json.MarshalIndent(l, "", "  ")
+	return os.WriteFile(filepath.Join(root, LockFile), append(data, '\n'), 0644)
+}
+
+// Find returns the lock entry for a manifest source, or nil.
+func (l *Lockfile) Find(source string) *LockEntry {
+	for i := range l.Skills {
+		if l.Skills[i].Source == source {
+			return &l.Skills[i]
+		}
+	}
+	return nil
+}
+
+// NewLockEntry builds a lock entry from a fetched gist and its install metadata.
+func NewLockEntry(source string, g *Gist, meta *SkillMeta) LockEntry {
+	return LockEntry{
+		Source:    source,
+		Name:      meta.Name,
+		Provider:  meta.EffectiveProvider(),
+		Host:      meta.Host,
+		GistID:    meta.GistID,
+		CommitSHA: meta.CommitSHA,
+		Files:     GistFileHashes(g),
+	}
+}
+
+// HashContent returns the lockfile hash of a file's content.
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// GistFileHashes hashes each gist file under the path it is installed to.
+func GistFileHashes(g *Gist) map[string]string {
+	return hashFiles(GistInstallFiles(g))
+}
+
+// InstalledFileHashes hashes every file of an installed skill except its metadata.
+func InstalledFileHashes(name string) (map[string]string, error) {
+	files, err := InstalledFiles(name)
+	if err != nil {
+		return nil, err
+	}
+	return hashFiles(files), nil
+}
+
+func hashFiles(files map[string]string) map[string]string {
+	hashes := make(map[string]string, len(files))
+	for path, content := range files {
+		hashes[path] = HashContent([]byte(content))
+	}
+	return hashes
+}
+
+// DiffHashes compares two file hash sets and returns sorted, human-readable
+// differences ("+ added", "- removed", "~ changed"). Empty means identical.
+func DiffHashes(want, got map[string]string) []string {
+	var diffs []string
+	for path, h := range want {
+		g, ok := got[path]
+		switch {
+		case !ok:
+			diffs = append(diffs, "- "+path)
+		case g != h:
+			diffs = append(diffs, "~ "+path)
+		}
+	}
+	for path := range got {
+		if _, ok := want[path]; !ok {
+			diffs = append(diffs, "+ "+path)
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i][2:] < diffs[j][2:] })
+	return diffs
+}
@@ -0,0 +1,63 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGistFileHashes(t *testing.T) {
+	g := &Gist{Files: map[string]GistFile{
+		"weather.skill.md":  {Content: "skill"},
+		"scripts--setup.sh": {Content: "echo hi"},
+	}}
+	hashes := GistFileHashes(g)
+	if len(hashes) != 2 {
+		t.Fatalf("GistFileHashes() = %v, want 2 entries", hashes)
+	}
+	if hashes["SKILL.md"] != HashContent([]byte("skill")) {
+		t.Errorf("SKILL.md hash = %q", hashes["SKILL.md"])
+	}
+	if hashes["scripts/setup.sh"] != HashContent([]byte("echo hi")) {
+		t.Errorf("scripts/setup.sh hash = %q", hashes["scripts/setup.sh"])
+	}
+}
+
+func TestDiffHashes(t *testing.T) {
+	want := map[string]string{"SKILL.md": "a", "gone.md": "b", "changed.sh": "c"}
+	got := map[string]string{"SKILL.md": "a", "changed.sh": "x", "extra.md": "d"}
+	diffs := DiffHashes(want, got)
+	expected := []string{"~ changed.sh", "+ extra.md", "- gone.md"}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("DiffHashes() = %v, want %v", diffs, expected)
+	}
+	if d := DiffHashes(want, want); len(d) != 0 {
+		t.Errorf("DiffHashes(identical) = %v, want none", d)
+	}
+}
+
+func TestLockfileFind(t *testing.T) {
+	l := &Lockfile{Skills: []LockEntry{{Source: "abc123", Name: "one"}, {Source: "def456@beef", Name: "two"}}}
+	if e := l.Find("def456@beef"); e == nil || e.Name != "two" {
+		t.Errorf("Find(def456@beef) = %v", e)
+	}
+	if e := l.Find("def456"); e != nil {
+		t.Errorf("Find(def456) = %v, want nil", e)
+	}
+}
+
+func TestFindGitRoot(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".git"), 0755)
+	sub := filepath.Join(root, "a", "b")
+	os.MkdirAll(sub, 0755)
+
+	got, err := FindGitRoot(sub)
+	if err != nil || got != root {
+		t.Errorf("FindGitRoot() = %q, %v, want %q", got, err, root)
+	}
+	if _, err := FindGitRoot(t.TempDir()); err == nil {
+		t.Error("expected an error outside a git repository")
+	}
+}
@@ -5,25 +5,73 @@ import (
 	"strings"
 )
 
-// Provider abstracts snippet storage backends (GitHub Gists, GitLab Snippets).
+// Provider abstracts snippet storage backends (GitHub Gists, GitLab Snippets,
+// Gitea/Forgejo repositories, GitHub repositories, plain git remotes, local
+// folders and archives).
 type Provider interface {
 	Name() string
+	// Host is the server the provider talks to, or "" for the public default.
+	Host() string
 	FetchSnippet(id string) (*Gist, error)
+	FetchSnippetRevision(id, revision string) (*Gist, error)
 	CreateSnippet(description string, files map[string]string, public bool) (*Gist, error)
+	// UpdateSnippet replaces the description and files of an existing
+	// snippet: listed files are added or changed, all others are deleted.
+	UpdateSnippet(id, description string, files map[string]string) (*Gist, error)
 	SearchSnippets(query string) ([]Gist, error)
 	AuthenticatedUser() string
 }
 
-var gitlabSnippetRe = regexp.MustCompile(`gitlab\.com/(?:-/)?snippets/(\d+)`)
+// RevisionResolver is implemented by providers that can look up the latest
+// revision of a snippet without fetching its files.
+type RevisionResolver interface {
+	LatestRevision(id string) (string, error)
+}
+
+var (
+	gitlabSnippetRe        = regexp.MustCompile(`^(?:-/)?snippets/(\d+)$`)
+	gitlabProjectSnippetRe = regexp.MustCompile(`^(.+)/-/snippets/(\d+)$`)
+)
 
 // DetectProvider examines a URL or ID and returns the appropriate provider and extracted ID.
 func DetectProvider(input string) (Provider, string) {
 	input = strings.TrimSpace(input)
 
-	if strings.Contains(input, "gitlab.com") {
-		if m := gitlabSnippetRe.FindStringSubmatch(input); len(m) == 2 {
-			return &GitLabProvider{}, m[1]
-		}
+	cfg, err := LoadConfig()
+	if err != nil {
+		cfg = &Config{}
+	}
+
+	if isArchive(input) {
+		return &ArchiveProvider{}, archiveID(input)
+	}
+
+	if path, ok := localPath(input); ok {
+		return &LocalProvider{}, path
+	}
+
+	if host, id, ok := parseGitLabSnippetURL(input, cfg.GitLabHosts); ok {
+		return &GitLabProvider{HostName: host}, id
+	}
+
+	if host, id, ok := parseEnterpriseGistURL(input, cfg.GitHubHosts); ok {
+		return &GitHubProvider{HostName: host}, id
+	}
+
+	if host, id, ok := parseGiteaURL(input, cfg.GiteaHosts); ok {
+		return &GiteaProvider{HostName: host}, id
+	}
+
+	if host, id, ok := parseGitHubRepoURL(input, cfg.GitHubHosts); ok {
+		return &RepoProvider{HostName: host}, id
+	}
+
+	if isGitURL(input) {
+		return &GitProvider{}, input
+	}
+
+	if isRepoShorthand(input) {
+		return &RepoProvider{}, input
 	}
 
 	// Default to GitHub — ParseGistID handles gist.github.com URLs and bare IDs
@@ -32,10 +80,155 @@ func DetectProvider(input string) (Provider, string) {
 
 // ProviderByName returns a provider by name string. Defaults to GitHub.
 func ProviderByName(name string) Provider {
+	return NewProvider(name, "")
+}
+
+// NewProvider returns a provider by name for the given host ("" for the
+// public default). Defaults to GitHub.
+func NewProvider(name, host string) Provider {
 	switch strings.ToLower(name) {
 	case "gitlab":
-		return &GitLabProvider{}
+		return &GitLabProvider{HostName: host}
+	case "gitea", "forgejo":
+		return &GiteaProvider{HostName: host}
+	case "repo":
+		return &RepoProvider{HostName: host}
+	case "git":
+		return &GitProvider{}
+	case "local":
+		return &LocalProvider{}
+	case "archive":
+		return &ArchiveProvider{}
 	default:
-		return &GitHubProvider{}
+		return &GitHubProvider{HostName: host}
+	}
+}
+
+// SnippetSource returns a reference that DetectProvider resolves to the
+// snippet id of the named provider on host, for listing an installed skill
+// elsewhere. ok is false for local files and for snippets no reference
+// resolves back to.
+func SnippetSource(provider, host, id string) (source string, ok bool) {
+	base := "https://" + host
+	if strings.Contains(host, "://") {
+		base = host
+	}
+	ref, rev := SplitRevision(id)
+	parts := strings.Split(ref, "/")
+	switch strings.ToLower(provider) {
+	case "github":
+		source = id
+		if host != "" {
+			source = base + "/gist/" + id
+		}
+	case "gitlab":
+		if host == "" {
+			base = "https://gitlab.com"
+		}
+		source = base + "/-/snippets/" + id
+		if i := strings.LastIndex(id, "/"); i >= 0 {
+			source = base + "/" + id[:i] + "/-/snippets/" + id[i+1:]
+		}
+	case "gitea", "forgejo", "repo":
+		if strings.EqualFold(provider, "repo") && host == "" {
+			source = id
+			break
+		}
+		if len(parts) < 2 {
+			return "", false
+		}
+		source = base + "/" + parts[0] + "/" + parts[1]
+		if rev != "" {
+			tree := "/tree/" + rev
+			if !strings.EqualFold(provider, "repo") {
+				tree = "/src/commit/" + rev
+			}
+			source += tree + strings.TrimSuffix("/"+strings.Join(parts[2:], "/"), "/")
+		}
+	case "git":
+		source = id
+	case "archive":
+		if strings.HasPrefix(id, "http://") || strings.HasPrefix(id, "https://") {
+			source = id
+		}
+	}
+	if source == "" {
+		return "", false
+	}
+	// Only hand out references that come back to the same snippet
+	if p, got := DetectProvider(source); p.Name() != NewProvider(provider, host).Name() || p.Host() != host || got != id {
+		return "", false
+	}
+	return source, true
+}
+
+// splitURL strips the scheme from a URL and returns its host and path
+// without leading or trailing slashes.
+func splitURL(input string) (string, string, bool) {
+	rest := input
+	if i := strings.Index(rest, "://"); i >= 0 {
+		rest = rest[i+3:]
+	}
+	rest = strings.TrimRight(rest, "/")
+	host, path, ok := strings.Cut(rest, "/")
+	if !ok || path == "" {
+		return "", "", false
+	}
+	return strings.ToLower(host), path, true
+}
+
+// parseGitLabSnippetURL matches snippet URLs on gitlab.com or a configured
+// self-hosted GitLab. Personal snippets (/-/snippets/<id>) yield the numeric
+// ID; project snippets (/<group>/<project>/-/snippets/<id>) yield
+// "<group>/<project>/<id>". The returned host is "" for gitlab.com.
+func parseGitLabSnippetURL(input string, hosts []string) (string, string, bool) {
+	hostPart, path, ok := splitURL(input)
+	if !ok {
+		return "", "", false
+	}
+	host := ""
+	if hostPart != "gitlab.com" {
+		found := false
+		for _, h := range hosts {
+			if strings.EqualFold(strings.TrimRight(h, "/"), hostPart) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", "", false
+		}
+		host = hostPart
+	}
+	if m := gitlabSnippetRe.FindStringSubmatch(path); m != nil {
+		return host, m[1], true
+	}
+	if m := gitlabProjectSnippetRe.FindStringSubmatch(path); m != nil {
+		return host, m[1] + "/" + m[2], true
+	}
+	return "", "", false
+}
+
+// parseEnterpriseGistURL matches gist URLs on configured GitHub Enterprise
+// hosts, either https://<host>/gist/<id> or, with subdomain isolation,
+// https://gist.<host>/<user>/<id>.
+func parseEnterpriseGistURL(input string, hosts []string) (string, string, bool) {
+	hostPart, path, ok := splitURL(input)
+	if !ok {
+		return "", "", false
+	}
+	segs := strings.Split(path, "/")
+	id := segs[len(segs)-1]
+	for _, h := range hosts {
+		h = strings.ToLower(strings.TrimRight(h, "/"))
+		switch hostPart {
+		case h:
+			if segs[0] == "gist" && len(segs) > 1 {
+				return h, id, true
+			}
+		case "gist." + h:
+			return h, id, true
+		}
 	}
+	return "", "", false
 }
@@ -1,6 +1,10 @@
 package internal
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
 
 func TestDetectProvider(t *testing.T) {
 	tests := []struct {
@@ -46,3 +50,101 @@ func TestProviderByName(t *testing.T) {
 		t.Errorf("ProviderByName(unknown) = %q, want github", p.Name())
 	}
 }
+
+func TestDetectProvider_Enterprise(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	os.MkdirAll(SkillsBasePath(), 0755)
+	os.WriteFile(filepath.Join(SkillsBasePath(), "config.json"), []byte(`{"github_hosts": ["ghe.example.com"]}`), 0644)
+
+	tests := []struct {
+		input    string
+		wantHost string
+		wantID   string
+	}{
+		{"https://ghe.example.com/gist/abc123", "ghe.example.com", "abc123"},
+		{"https://ghe.example.com/gist/nico/abc123/", "ghe.example.com", "abc123"},
+		{"https://gist.ghe.example.com/nico/abc123", "ghe.example.com", "abc123"},
+		{"https://gist.github.com/nico/abc123", "", "abc123"},
+		{"https://other.example.com/gist/abc123", "", "https://other.example.com/gist/abc123"},
+	}
+	for _, tt := range tests {
+		provider, id := DetectProvider(tt.input)
+		if provider.Name() != "github" || provider.Host() != tt.wantHost || id != tt.wantID {
+			t.Errorf("DetectProvider(%q) = (%s@%q, %q), want (github@%q, %q)", tt.input, provider.Name(), provider.Host(), id, tt.wantHost, tt.wantID)
+		}
+	}
+}
+
+func TestNewGitHubClientForHost(t *testing.T) {
+	t.Setenv("GH_ENTERPRISE_TOKEN", "ghe-token")
+	c := NewGitHubClientForHost("ghe.example.com")
+	if c.BaseURL != "https://ghe.example.com/api/v3" {
+		t.Errorf("BaseURL = %q", c.BaseURL)
+	}
+	if c.Token != "ghe-token" {
+		t.Errorf("Token = %q, want ghe-token", c.Token)
+	}
+}
+
+func TestDetectProvider_SelfHostedGitLab(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	os.MkdirAll(SkillsBasePath(), 0755)
+	os.WriteFile(filepath.Join(SkillsBasePath(), "config.json"), []byte(`{"gitlab_hosts": ["git.corp.example"]}`), 0644)
+
+	tests := []struct {
+		input        string
+		wantProvider string
+		wantHost     string
+		wantID       string
+	}{
+		{"https://git.corp.example/-/snippets/42", "gitlab", "git.corp.example", "42"},
+		{"https://git.corp.example/snippets/42/", "gitlab", "git.corp.example", "42"},
+		{"https://git.corp.example/platform/tools/-/snippets/7", "gitlab", "git.corp.example", "platform/tools/7"},
+		{"https://gitlab.com/group/sub/project/-/snippets/9", "gitlab", "", "group/sub/project/9"},
+		{"https://unknown.example/-/snippets/42", "github", "", "https://unknown.example/-/snippets/42"},
+	}
+	for _, tt := range tests {
+		provider, id := DetectProvider(tt.input)
+		if provider.Name() != tt.wantProvider || provider.Host() != tt.wantHost || id != tt.wantID {
+			t.Errorf("DetectProvider(%q) = (%s@%q, %q), want (%s@%q, %q)", tt.input, provider.Name(), provider.Host(), id, tt.wantProvider, tt.wantHost, tt.wantID)
+		}
+	}
+}
+
+func TestSnippetEndpoint(t *testing.T) {
+	if got := snippetEndpoint("42"); got != "/snippets/42" {
+		t.Errorf("snippetEndpoint(42) = %q", got)
+	}
+	if got := snippetEndpoint("platform/tools/7"); got != "/projects/platform%2Ftools/snippets/7" {
+		t.Errorf("snippetEndpoint(platform/tools/7) = %q", got)
+	}
+}
+
+func TestSnippetSource(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	os.MkdirAll(SkillsBasePath(), 0755)
+	os.WriteFile(filepath.Join(SkillsBasePath(), "config.json"), []byte(`{"gitlab_hosts": ["git.corp.example"], "gitea_hosts": ["https://code.example"]}`), 0644)
+
+	tests := []struct {
+		provider, host, id string
+		want               string
+	}{
+		{"github", "", "abc123", "abc123"},
+		{"gitlab", "", "42", "https://gitlab.com/-/snippets/42"},
+		{"gitlab", "git.corp.example", "platform/tools/7", "https://git.corp.example/platform/tools/-/snippets/7"},
+		{"repo", "", "team/skills/weather@v1", "team/skills/weather@v1"},
+		{"gitea", "https://code.example", "team/skills/pdf@main", "https://code.example/team/skills/src/commit/main/pdf"},
+		{"archive", "", "https://example.com/demo.zip", "https://example.com/demo.zip"},
+	}
+	for _, tt := range tests {
+		got, ok := SnippetSource(tt.provider, tt.host, tt.id)
+		if !ok || got != tt.want {
+			t.Errorf("SnippetSource(%s, %q, %q) = %q, %v, want %q", tt.provider, tt.host, tt.id, got, ok, tt.want)
+		}
+	}
+	for _, provider := range []string{"local", "archive"} {
+		if got, ok := SnippetSource(provider, "", "/home/me/skills/demo"); ok {
+			t.Errorf("SnippetSource(%s) = %q, want no source for a local path", provider, got)
+		}
+	}
+}
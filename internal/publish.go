@@ -0,0 +1,133 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Gist limits a published skill must stay within. The gists API truncates
+// file contents above MaxGistFileSize and lists at most MaxGistFiles files.
+const (
+	MaxGistFileSize = 1 << 20
+	MaxGistFiles    = 300
+)
+
+// PublishFile is a file of a local skill folder as it will be uploaded.
+type PublishFile struct {
+	Path    string // slash-separated path in the skill folder
+	Name    string // flattened gist filename
+	Content string // uploaded content; base64 for binary files
+	Size    int64  // size of the file on disk
+	Binary  bool   // encoded with EncodeBinary under a BinarySuffix name
+}
+
+// CollectPublishFiles walks the skill folder dir and returns the files
+// publish and fork upload, sorted by path. Dotfiles and anything matched by
+// the folder's .skillignore (and .gitignore, if withGitignore is set) are
+// left out, SKILL.md is renamed to <skillName>.skill.md and binary files are
+// base64-encoded under a BinarySuffix name. Files above the gist limits are
+// reported together in one error.
+func CollectPublishFiles(dir, skillName string, withGitignore bool) ([]PublishFile, error) {
+	var files []PublishFile
+	var tooLarge []string
+	err := walkSkillFiles(dir, withGitignore, func(rel, path string, fi os.FileInfo) error {
+		if fi.Size() > MaxGistFileSize {
+			tooLarge = append(tooLarge, fmt.Sprintf("%s (%s)", rel, FormatSize(fi.Size())))
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// A file already named *.b64 is encoded too, so install does not
+		// mistake its text for an encoded binary file
+		binary := IsBinary(content) || IsBinaryName(fi.Name())
+		f := PublishFile{Path: rel, Name: publishName(rel, skillName, binary), Content: string(content), Size: fi.Size(), Binary: binary}
+		if binary {
+			f.Content = EncodeBinary(content)
+			if len(f.Content) > MaxGistFileSize {
+				tooLarge = append(tooLarge, fmt.Sprintf("%s (%s encoded)", rel, FormatSize(int64(len(f.Content)))))
+				return nil
+			}
+		}
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(tooLarge) > 0 {
+		return nil, fmt.Errorf("files larger than the %s gist limit (add them to %s):\n  %s",
+			FormatSize(MaxGistFileSize), SkillIgnoreFile, strings.Join(tooLarge, "\n  "))
+	}
+	if len(files) > MaxGistFiles {
+		return nil, fmt.Errorf("%d files is more than the %d a gist can hold (exclude some in %s)", len(files), MaxGistFiles, SkillIgnoreFile)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// walkSkillFiles calls fn with the slash-separated relative path, full path
+// and file info of every regular file in the skill folder dir that is
+// published: dotfiles and files matched by the ignore rules are skipped.
+func walkSkillFiles(dir string, withGitignore bool, fn func(rel, path string, fi os.FileInfo) error) error {
+	rules, err := SkillIgnoreRules(dir, withGitignore)
+	if err != nil {
+		return err
+	}
+	return filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			return nil
+		}
+		rel, _ := filepath.Rel(dir, path)
+		rel = filepath.ToSlash(rel)
+		if strings.HasPrefix(fi.Name(), ".") || rules.Match(rel, fi.IsDir()) {
+			if fi.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if fi.IsDir() || !fi.Mode().IsRegular() {
+			return nil
+		}
+		return fn(rel, path, fi)
+	})
+}
+
+// publishName returns the gist filename a skill file is published as.
+func publishName(rel, skillName string, binary bool) string {
+	name := FlattenFilename(rel)
+	if name == "SKILL.md" {
+		name = SkillFileName(skillName)
+	}
+	if binary {
+		name += BinarySuffix
+	}
+	return name
+}
+
+// PublishPayload maps gist filenames to content for CreateSnippet.
+func PublishPayload(files []PublishFile) map[string]string {
+	payload := make(map[string]string, len(files))
+	for _, f := range files {
+		payload[f.Name] = f.Content
+	}
+	return payload
+}
+
+// FormatSize formats a byte count for humans, e.g. "512 B" or "1.5 KB".
+func FormatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
@@ -0,0 +1,97 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCollectPublishFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"SKILL.md":                 "---\nname: demo\n---\n",
+		"scripts/run.sh":           "echo hi",
+		"node_modules/x/index.js":  "x",
+		"build/out.txt":            "out",
+		".env":                     "TOKEN=x",
+		".git/config":              "[core]",
+		".skillignore":             "node_modules/\n",
+		".gitignore":               "build/\n",
+		"scripts/.cache/state.txt": "s",
+	})
+
+	files, err := CollectPublishFiles(dir, "demo", false)
+	if err != nil {
+		t.Fatalf("CollectPublishFiles() error: %v", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "demo.skill.md,build--out.txt,scripts--run.sh" {
+		t.Errorf("files = %s", got)
+	}
+
+	files, err = CollectPublishFiles(dir, "demo", true)
+	if err != nil {
+		t.Fatalf("CollectPublishFiles(gitignore) error: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("with .gitignore got %d files, want 2", len(files))
+	}
+}
+
+func TestCollectPublishFiles_TooLarge(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"SKILL.md": "---\nname: demo\n---\n",
+		"big.bin":  strings.Repeat("x", MaxGistFileSize+1),
+	})
+	_, err := CollectPublishFiles(dir, "demo", false)
+	if err == nil || !strings.Contains(err.Error(), "big.bin") {
+		t.Fatalf("CollectPublishFiles() error = %v, want big.bin refused", err)
+	}
+
+	writeTestFiles(t, dir, map[string]string{".skillignore": "*.bin\n"})
+	if _, err := CollectPublishFiles(dir, "demo", false); err != nil {
+		t.Errorf("ignored large file still refused: %v", err)
+	}
+}
+
+func TestCollectPublishFiles_B64Name(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"SKILL.md":           "---\nname: demo\n---\n",
+		"fixtures/token.b64": "not base64 at all\n",
+	})
+	files, err := CollectPublishFiles(dir, "demo", false)
+	if err != nil {
+		t.Fatalf("CollectPublishFiles() error: %v", err)
+	}
+
+	// Installing what was published gives back the file as it was
+	g := &Gist{ID: "abc123", Files: map[string]GistFile{}}
+	for name, content := range PublishPayload(files) {
+		g.Files[name] = GistFile{Filename: name, Content: content}
+	}
+	if errs := CheckGistFiles(g); len(errs) > 0 {
+		t.Fatalf("CheckGistFiles() = %v", errs)
+	}
+	if got := GistInstallFiles(g)["fixtures/token.b64"]; got != "not base64 at all\n" {
+		t.Errorf("fixtures/token.b64 = %q", got)
+	}
+}
@@ -0,0 +1,278 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// NativeFormat is a tool that does not read SKILL.md folders but its own
+// instruction files. Linking a skill to it renders SKILL.md into that file.
+type NativeFormat struct {
+	Tool string
+	// File is the path the tool reads, relative to the project root, with
+	// <name> standing for the skill name.
+	File string
+	// Shared files hold one marked section per skill next to the user's own
+	// content; other files belong to a single skill.
+	Shared bool
+	render func(name string, fm *FrontMatter, body, skillDir string) string
+}
+
+// NativeFormats returns the tools link renders skills for.
+func NativeFormats() []NativeFormat {
+	return []NativeFormat{
+		{"cursor-rules", filepath.Join(".cursor", "rules", "<name>.mdc"), false, renderCursorRule},
+		{"copilot-instructions", filepath.Join(".github", "copilot-instructions.md"), true, renderSection},
+		{"agents-md", "AGENTS.md", true, renderSection},
+	}
+}
+
+// NativeFormatByName returns the native format for a tool name.
+func NativeFormatByName(tool string) (NativeFormat, bool) {
+	for _, f := range NativeFormats() {
+		if f.Tool == tool {
+			return f, true
+		}
+	}
+	return NativeFormat{}, false
+}
+
+// NativeRender records a file generated from a skill for a native format,
+// so update can regenerate it and remove can clean it up. Path is relative
+// to Root and must be the format's File for the skill.
+type NativeRender struct {
+	Tool string `json:"tool"`
+	Root string `json:"root"`
+	Path string `json:"path"`
+}
+
+// target checks the render against its format's layout and returns the
+// format and the file to write. Metadata can come from a bundle, so nothing
+// else may be written or deleted.
+func (r NativeRender) target(name string) (NativeFormat, string, error) {
+	f, ok := NativeFormatByName(r.Tool)
+	if !ok {
+		return NativeFormat{}, "", fmt.Errorf("unknown tool %q", r.Tool)
+	}
+	if !filepath.IsAbs(r.Root) || filepath.Clean(filepath.FromSlash(r.Path)) != f.path(name) {
+		return NativeFormat{}, "", fmt.Errorf("%s render at %s does not match %s", r.Tool, filepath.Join(r.Root, r.Path), f.File)
+	}
+	return f, filepath.Join(r.Root, f.path(name)), nil
+}
+
+// path returns the format's file for a skill, relative to the project root.
+func (f NativeFormat) path(name string) string {
+	return strings.ReplaceAll(f.File, "<name>", name)
+}
+
+const generatedNote = "<!-- Generated by gh skill from the %s skill; edit the skill, not this file. -->"
+
+// RenderNativeSkill renders an installed skill into the file the tool of f
+// reads under root, and records the file in the skill's metadata. It returns
+// the path written.
+func RenderNativeSkill(name string, f NativeFormat, root string) (string, error) {
+	meta, err := GetSkill(name)
+	if err != nil {
+		return "", err
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	render := NativeRender{Tool: f.Tool, Root: root, Path: filepath.ToSlash(f.path(name))}
+	_, path, err := render.target(name)
+	if err != nil {
+		return "", err
+	}
+	if err := writeNativeRender(name, f, path); err != nil {
+		return "", err
+	}
+	for _, r := range meta.Renders {
+		if r == render {
+			return path, nil
+		}
+	}
+	meta.Renders = append(meta.Renders, render)
+	return path, SaveSkillMeta(meta)
+}
+
+// RefreshNativeRenders regenerates the native-format files of a skill, e.g.
+// after an update changed its SKILL.md.
+func RefreshNativeRenders(meta *SkillMeta) error {
+	var errs []error
+	for _, r := range meta.Renders {
+		f, path, err := r.target(meta.Name)
+		if err == nil {
+			err = writeNativeRender(meta.Name, f, path)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RemoveNativeRenders deletes the native-format files of a skill, or its
+// sections of shared files.
+func RemoveNativeRenders(meta *SkillMeta) error {
+	var errs []error
+	for _, r := range meta.Renders {
+		f, path, err := r.target(meta.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !f.Shared {
+			if strings.Contains(string(data), fmt.Sprintf(generatedNote, meta.Name)) {
+				err = os.Remove(path)
+			}
+		} else if content := replaceSection(string(data), meta.Name, ""); strings.TrimSpace(content) == "" {
+			err = os.Remove(path)
+		} else {
+			err = os.WriteFile(path, []byte(content), 0644)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func writeNativeRender(name string, f NativeFormat, path string) error {
+	skillDir := filepath.Join(SkillsBasePath(), name)
+	data, err := os.ReadFile(filepath.Join(skillDir, "SKILL.md"))
+	if err != nil {
+		return fmt.Errorf("skill %q has no SKILL.md", name)
+	}
+	fm, err := ParseFrontMatter(string(data))
+	if err != nil {
+		return err
+	}
+	rendered := f.render(name, fm, SkillBody(string(data)), skillDir)
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if f.Shared {
+		rendered = replaceSection(string(existing), name, rendered)
+	} else if len(existing) > 0 && !strings.Contains(string(existing), fmt.Sprintf(generatedNote, name)) {
+		return fmt.Errorf("%s exists and was not generated by gh skill", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(rendered), 0644)
+}
+
+// SkillBody returns SKILL.md content without its front matter.
+func SkillBody(content string) string {
+	lines := strings.Split(content, "\n")
+	if strings.TrimSpace(lines[0]) != "---" {
+		return content
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return strings.TrimLeft(strings.Join(lines[i+1:], "\n"), "\n")
+		}
+	}
+	return content
+}
+
+// renderCursorRule renders a Cursor rule the agent applies when the
+// description matches, like a skill.
+func renderCursorRule(name string, fm *FrontMatter, body, skillDir string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "---\ndescription: %q\nglobs:\nalwaysApply: false\n---\n", fm.Description)
+	fmt.Fprintf(&b, generatedNote+"\n\n", name)
+	b.WriteString(filesNote(skillDir))
+	b.WriteString(strings.TrimRight(body, "\n") + "\n")
+	return b.String()
+}
+
+// renderSection renders a skill as a section of a shared instructions file,
+// with its headings nested below the section heading.
+func renderSection(name string, fm *FrontMatter, body, skillDir string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Skill: %s\n\n", name)
+	if fm.Description != "" {
+		fmt.Fprintf(&b, "Use when: %s\n\n", strings.TrimSpace(fm.Description))
+	}
+	b.WriteString(filesNote(skillDir))
+	b.WriteString(strings.TrimRight(nestHeadings(body), "\n") + "\n")
+	return b.String()
+}
+
+// filesNote points at the installed skill when it has files besides
+// SKILL.md, since relative paths in the body are relative to it.
+func filesNote(skillDir string) string {
+	files, err := readSkillFiles(skillDir)
+	if err != nil || len(files) <= 1 {
+		return ""
+	}
+	return fmt.Sprintf("Paths below are relative to `%s`.\n\n", skillDir)
+}
+
+// nestHeadings moves Markdown headings outside code fences two levels down,
+// below the section's own ## heading.
+func nestHeadings(body string) string {
+	lines := strings.Split(body, "\n")
+	fence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = !fence
+		}
+		if !fence && strings.HasPrefix(line, "#") {
+			lines[i] = "##" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func sectionMarkers(name string) (begin, end string) {
+	return "<!-- gh-skill:begin " + name + " -->", "<!-- gh-skill:end " + name + " -->"
+}
+
+// replaceSection replaces the marked section for a skill in content with
+// section, appending it if there is none. An empty section removes it.
+func replaceSection(content, name, section string) string {
+	begin, end := sectionMarkers(name)
+	var block string
+	if section != "" {
+		block = begin + "\n" + fmt.Sprintf(generatedNote, name) + "\n\n" + strings.TrimRight(section, "\n") + "\n" + end + "\n"
+	}
+	i := strings.Index(content, begin)
+	j := strings.Index(content, end)
+	if i >= 0 && j > i {
+		rest := strings.TrimPrefix(content[j+len(end):], "\n")
+		if block == "" {
+			before, after := strings.TrimRight(content[:i], "\n"), strings.TrimLeft(rest, "\n")
+			switch {
+			case before == "":
+				return after
+			case after == "":
+				return before + "\n"
+			}
+			return before + "\n\n" + after
+		}
+		return content[:i] + block + rest
+	}
+	if block == "" {
+		return content
+	}
+	if strings.TrimSpace(content) == "" {
+		return block
+	}
+	return strings.TrimRight(content, "\n") + "\n\n" + block
+}
@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSkillBody(t *testing.T) {
+	if got := SkillBody("---\nname: demo\n---\n\n# Demo\n"); got != "# Demo\n" {
+		t.Errorf("SkillBody() = %q", got)
+	}
+	if got := SkillBody("# No front matter\n"); got != "# No front matter\n" {
+		t.Errorf("SkillBody() = %q", got)
+	}
+}
+
+func TestReplaceSection(t *testing.T) {
+	content := replaceSection("# Project rules\n", "demo", "## Skill: demo\n")
+	content = replaceSection(content, "other", "## Skill: other\n")
+	if !strings.HasPrefix(content, "# Project rules\n\n<!-- gh-skill:begin demo -->") {
+		t.Errorf("section not appended after existing content:\n%s", content)
+	}
+
+	updated := replaceSection(content, "demo", "## Skill: demo v2\n")
+	if strings.Count(updated, "gh-skill:begin demo") != 1 || !strings.Contains(updated, "demo v2") {
+		t.Errorf("section not replaced in place:\n%s", updated)
+	}
+	if !strings.Contains(updated, "## Skill: other") {
+		t.Errorf("other section lost:\n%s", updated)
+	}
+
+	removed := replaceSection(replaceSection(updated, "demo", ""), "other", "")
+	if removed != "# Project rules\n" {
+		t.Errorf("after removing both sections = %q", removed)
+	}
+}
+
+func TestNativeRenders(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	if _, err := InstallSkill(testGist("aaa111", "# Demo\n\nfirst\n")); err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	agents := filepath.Join(root, "AGENTS.md")
+	os.WriteFile(agents, []byte("# Agents\n\nKeep it short.\n"), 0644)
+
+	cursor, _ := NativeFormatByName("cursor-rules")
+	agentsMD, _ := NativeFormatByName("agents-md")
+	mdc, err := RenderNativeSkill("demo", cursor, root)
+	if err != nil {
+		t.Fatalf("RenderNativeSkill(cursor-rules) error: %v", err)
+	}
+	if want := filepath.Join(root, ".cursor", "rules", "demo.mdc"); mdc != want {
+		t.Errorf("path = %s, want %s", mdc, want)
+	}
+	if _, err := RenderNativeSkill("demo", agentsMD, root); err != nil {
+		t.Fatalf("RenderNativeSkill(agents-md) error: %v", err)
+	}
+	data, _ := os.ReadFile(agents)
+	if !strings.Contains(string(data), "Keep it short.") || !strings.Contains(string(data), "### Demo") {
+		t.Errorf("AGENTS.md = %s", data)
+	}
+
+	// An update regenerates the rendered files from the new SKILL.md
+	meta, err := InstallSkill(testGist("bbb222", "# Demo\n\nsecond\n"))
+	if err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	if len(meta.Renders) != 2 {
+		t.Fatalf("Renders = %+v, want 2", meta.Renders)
+	}
+	data, _ = os.ReadFile(mdc)
+	if !strings.Contains(string(data), "second") || !strings.Contains(string(data), "alwaysApply: false") {
+		t.Errorf("demo.mdc = %s", data)
+	}
+
+	if err := RemoveSkill("demo"); err != nil {
+		t.Fatalf("RemoveSkill() error: %v", err)
+	}
+	if _, err := os.Stat(mdc); !os.IsNotExist(err) {
+		t.Errorf("demo.mdc not removed: %v", err)
+	}
+	data, _ = os.ReadFile(agents)
+	if string(data) != "# Agents\n\nKeep it short.\n" {
+		t.Errorf("AGENTS.md after remove = %q", data)
+	}
+}
+
+func TestRenderNativeSkillKeepsUserFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	if _, err := InstallSkill(testGist("aaa111", "body")); err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	writeTestFiles(t, root, map[string]string{".cursor/rules/demo.mdc": "my own rule"})
+	cursor, _ := NativeFormatByName("cursor-rules")
+	if _, err := RenderNativeSkill("demo", cursor, root); err == nil {
+		t.Error("expected an error overwriting a rule gh skill did not generate")
+	}
+}
+
+func TestRefreshNativeRendersRejectsOtherFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	root := t.TempDir()
+	meta, err := InstallSkill(testGist("aaa111", "body"))
+	if err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	target := filepath.Join(root, ".bashrc")
+	os.WriteFile(target, []byte("export PATH\n"), 0644)
+
+	meta.Renders = []NativeRender{
+		{Tool: "agents-md", Root: root, Path: ".bashrc"},
+		{Tool: "agents-md", Root: root, Path: "../" + filepath.Base(root) + "/.bashrc"},
+		{Tool: "cursor-rules", Root: "relative", Path: ".cursor/rules/demo.mdc"},
+	}
+	if err := RefreshNativeRenders(meta); err == nil {
+		t.Error("expected errors for renders outside the format's layout")
+	}
+	if err := RemoveNativeRenders(meta); err == nil {
+		t.Error("expected errors removing renders outside the format's layout")
+	}
+	data, _ := os.ReadFile(target)
+	if string(data) != "export PATH\n" {
+		t.Errorf(".bashrc = %q, want it untouched", data)
+	}
+}
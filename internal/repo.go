@@ -0,0 +1,355 @@
+package internal
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// RepoProvider implements Provider for skills kept in GitHub repositories.
+//
+// Snippet IDs have the form "<owner>/<repo>[/<path>][@<ref>]". Without a
+// path, the directory containing SKILL.md is located automatically; without
+// a ref, the default branch is used.
+type RepoProvider struct {
+	HostName string
+	Client   *GitHubClient
+}
+
+func (p *RepoProvider) Name() string { return "repo" }
+
+func (p *RepoProvider) Host() string { return p.HostName }
+
+func (p *RepoProvider) client() *GitHubClient {
+	if p.Client != nil {
+		return p.Client
+	}
+	return NewGitHubClientForHost(p.HostName)
+}
+
+// splitRepoID splits "<owner>/<repo>[/<path>][@<ref>]" into its parts.
+func splitRepoID(id string) (repo, dir, ref string, err error) {
+	base, ref := SplitRevision(id)
+	parts := strings.SplitN(strings.Trim(base, "/"), "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid repository reference %q (want <owner>/<repo>[/<path>][@<ref>])", id)
+	}
+	repo = parts[0] + "/" + strings.TrimSuffix(parts[1], ".git")
+	if len(parts) == 3 {
+		dir = strings.Trim(parts[2], "/")
+	}
+	return repo, dir, ref, nil
+}
+
+type gitTreeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+}
+
+func (p *RepoProvider) FetchSnippet(id string) (*Gist, error) {
+	return p.fetch(id, "")
+}
+
+func (p *RepoProvider) FetchSnippetRevision(id, revision string) (*Gist, error) {
+	return p.fetch(id, revision)
+}
+
+// LatestRevision resolves the commit SHA the ID's ref (or the default
+// branch) currently points at, without downloading any files.
+func (p *RepoProvider) LatestRevision(id string) (string, error) {
+	repoName, _, ref, err := splitRepoID(id)
+	if err != nil {
+		return "", err
+	}
+	c := p.client()
+	if ref == "" {
+		var repo repoInfo
+		if err := c.Do("GET", "/repos/"+repoName, nil, &repo); err != nil {
+			return "", fmt.Errorf("failed to fetch repository %s: %w", repoName, err)
+		}
+		ref = repo.DefaultBranch
+	}
+	return p.resolveCommit(c, repoName, ref)
+}
+
+func (p *RepoProvider) resolveCommit(c *GitHubClient, repoName, ref string) (string, error) {
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	if err := c.Do("GET", fmt.Sprintf("/repos/%s/commits/%s", repoName, escapePath(ref)), nil, &commit); err != nil {
+		return "", fmt.Errorf("failed to resolve %s@%s: %w", repoName, ref, err)
+	}
+	return commit.SHA, nil
+}
+
+func (p *RepoProvider) fetch(id, revision string) (*Gist, error) {
+	repoName, dir, ref, err := splitRepoID(id)
+	if err != nil {
+		return nil, err
+	}
+	if revision != "" {
+		ref = revision
+	}
+	c := p.client()
+
+	var repo repoInfo
+	if err := c.Do("GET", "/repos/"+repoName, nil, &repo); err != nil {
+		return nil, fmt.Errorf("failed to fetch repository %s: %w", repoName, err)
+	}
+	if ref == "" {
+		ref = repo.DefaultBranch
+	}
+	sha, err := p.resolveCommit(c, repoName, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := p.listBlobs(c, repoName, sha, dir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for full := range blobs {
+		paths = append(paths, full)
+	}
+	sort.Strings(paths)
+	dir, files, err := locateSkillDir(paths, dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", repoName, err)
+	}
+
+	g := newRepoGist(id, repoName, dir, sha)
+	g.Description = repo.Description
+	g.HTMLURL = repo.HTMLURL
+	if dir != "" {
+		g.HTMLURL = fmt.Sprintf("%s/tree/%s/%s", repo.HTMLURL, sha, dir)
+	}
+	g.CreatedAt = repo.CreatedAt
+	g.UpdatedAt = repo.UpdatedAt
+	g.Owner.Login = repo.Owner.Login
+
+	for _, rel := range files {
+		var blob struct {
+			Content  string `json:"content"`
+			Encoding string `json:"encoding"`
+		}
+		full := path.Join(dir, rel)
+		if err := c.Do("GET", fmt.Sprintf("/repos/%s/git/blobs/%s", repoName, blobs[full]), nil, &blob); err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", full, err)
+		}
+		content := []byte(blob.Content)
+		if blob.Encoding == "base64" {
+			content, err = base64.StdEncoding.DecodeString(strings.ReplaceAll(blob.Content, "\n", ""))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode %s: %w", full, err)
+			}
+		}
+		g.Files[rel] = GistFile{Filename: rel, Content: string(content)}
+	}
+	return g, nil
+}
+
+type gitTree struct {
+	Tree      []gitTreeEntry `json:"tree"`
+	Truncated bool           `json:"truncated"`
+}
+
+// listBlobs maps the path of every file in the repository at commit sha to
+// its blob SHA. When the recursive listing is truncated, only dir's own
+// tree is listed, which must then be complete.
+func (p *RepoProvider) listBlobs(c *GitHubClient, repoName, sha, dir string) (map[string]string, error) {
+	var tree gitTree
+	if err := c.Do("GET", fmt.Sprintf("/repos/%s/git/trees/%s?recursive=1", repoName, sha), nil, &tree); err != nil {
+		return nil, fmt.Errorf("failed to list files in %s@%s: %w", repoName, ShortSHA(sha), err)
+	}
+	prefix := ""
+	if tree.Truncated {
+		if dir == "" {
+			return nil, fmt.Errorf("repository %s is too large to search; give the skill path as %s/<path>", repoName, repoName)
+		}
+		treeSHA := sha
+		for _, seg := range strings.Split(dir, "/") {
+			var level gitTree
+			if err := c.Do("GET", fmt.Sprintf("/repos/%s/git/trees/%s", repoName, treeSHA), nil, &level); err != nil {
+				return nil, fmt.Errorf("failed to list files in %s@%s: %w", repoName, ShortSHA(sha), err)
+			}
+			treeSHA = ""
+			for _, e := range level.Tree {
+				if e.Path == seg && e.Type == "tree" {
+					treeSHA = e.SHA
+				}
+			}
+			if treeSHA == "" {
+				return nil, fmt.Errorf("%s has no directory %s", repoName, dir)
+			}
+		}
+		tree = gitTree{}
+		if err := c.Do("GET", fmt.Sprintf("/repos/%s/git/trees/%s?recursive=1", repoName, treeSHA), nil, &tree); err != nil {
+			return nil, fmt.Errorf("failed to list files in %s/%s: %w", repoName, dir, err)
+		}
+		if tree.Truncated {
+			return nil, fmt.Errorf("%s/%s is too large to install from", repoName, dir)
+		}
+		prefix = dir + "/"
+	}
+
+	blobs := make(map[string]string)
+	for _, e := range tree.Tree {
+		if e.Type == "blob" {
+			blobs[prefix+e.Path] = e.SHA
+		}
+	}
+	return blobs, nil
+}
+
+func (p *RepoProvider) CreateSnippet(description string, files map[string]string, public bool) (*Gist, error) {
+	return nil, fmt.Errorf("publishing to a repository is not supported; commit the skill folder with git instead")
+}
+
+func (p *RepoProvider) UpdateSnippet(id, description string, files map[string]string) (*Gist, error) {
+	return nil, fmt.Errorf("updating a repository is not supported; push the changes with git instead")
+}
+
+func (p *RepoProvider) SearchSnippets(query string) ([]Gist, error) {
+	return p.client().SearchGists(query)
+}
+
+func (p *RepoProvider) AuthenticatedUser() string {
+	return p.client().AuthenticatedUser()
+}
+
+// newRepoGist returns an empty repository-backed Gist whose file names are
+// real paths relative to dir.
+func newRepoGist(id, repo, dir, sha string) *Gist {
+	return &Gist{
+		ID:        id,
+		Files:     make(map[string]GistFile),
+		History:   []GistRevision{{Version: sha}},
+		PathNames: true,
+		Repo:      repo,
+		RepoPath:  dir,
+	}
+}
+
+// locateSkillDir picks the skill directory among the file paths of a
+// repository and returns it with the paths of the files below it, relative
+// to that directory. If dir is empty, the directory containing SKILL.md (or
+// a <name>.skill.md, or a collection's gistskills.json) is used; the
+// repository root wins, and more than one other candidate is an error.
+// Dotfiles and dot directories are skipped.
+func locateSkillDir(paths []string, dir string) (string, []string, error) {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		candidates := make(map[string]bool)
+		for _, p := range paths {
+			base := path.Base(p)
+			if base == "SKILL.md" || IsSkillFile(base) || base == CollectionFile {
+				candidates[strings.TrimSuffix(path.Dir(p), ".")] = true
+			}
+		}
+		switch {
+		case candidates[""]:
+			dir = ""
+		case len(candidates) == 1:
+			for d := range candidates {
+				dir = d
+			}
+		case len(candidates) == 0:
+			return "", nil, fmt.Errorf("no SKILL.md found")
+		default:
+			var names []string
+			for d := range candidates {
+				names = append(names, d)
+			}
+			sort.Strings(names)
+			return "", nil, fmt.Errorf("multiple skills found (%s); include the skill's path in the reference", strings.Join(names, ", "))
+		}
+	}
+
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+	var files []string
+	for _, p := range paths {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		rel := strings.TrimPrefix(p, prefix)
+		if strings.HasPrefix(rel, ".") || strings.Contains(rel, "/.") {
+			continue // dotfiles are never part of a skill
+		}
+		files = append(files, rel)
+	}
+	if len(files) == 0 {
+		return "", nil, fmt.Errorf("no files found under %q", dir)
+	}
+	sort.Strings(files)
+	return dir, files, nil
+}
+
+// parseGitHubRepoURL matches repository URLs on github.com or a configured
+// GitHub Enterprise host: https://<host>/<owner>/<repo>[.git],
+// .../tree/<ref>[/<path>], .../blob/<ref>/<path>/SKILL.md and
+// git@<host>:<owner>/<repo>.git. The returned host is "" for github.com.
+func parseGitHubRepoURL(input string, hosts []string) (string, string, bool) {
+	if rest, ok := strings.CutPrefix(input, "git@"); ok {
+		h, p, found := strings.Cut(rest, ":")
+		if !found {
+			return "", "", false
+		}
+		input = "https://" + h + "/" + p
+	}
+	if !strings.Contains(input, "://") {
+		return "", "", false
+	}
+	hostPart, urlPath, ok := splitURL(input)
+	if !ok {
+		return "", "", false
+	}
+	host := ""
+	if hostPart != "github.com" {
+		found := false
+		for _, h := range hosts {
+			if strings.EqualFold(strings.TrimRight(h, "/"), hostPart) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", "", false
+		}
+		host = hostPart
+	}
+	segs := strings.Split(urlPath, "/")
+	if len(segs) < 2 || segs[0] == "gist" {
+		return "", "", false
+	}
+	id := segs[0] + "/" + strings.TrimSuffix(segs[1], ".git")
+	if len(segs) >= 4 && (segs[2] == "tree" || segs[2] == "blob") {
+		rest := segs[4:]
+		if segs[2] == "blob" && len(rest) > 0 {
+			rest = rest[:len(rest)-1] // the file's directory
+		}
+		if len(rest) > 0 {
+			id += "/" + strings.Join(rest, "/")
+		}
+		id += "@" + segs[3]
+	}
+	return host, id, true
+}
+
+// isRepoShorthand reports whether input looks like "<owner>/<repo>[/...]"
+// rather than a gist ID, URL or filesystem path.
+func isRepoShorthand(input string) bool {
+	base, _ := SplitRevision(input)
+	if strings.Contains(base, "://") || strings.HasPrefix(base, "git@") || strings.HasPrefix(base, "/") ||
+		strings.HasPrefix(base, ".") || strings.HasPrefix(base, "~") {
+		return false
+	}
+	parts := strings.Split(base, "/")
+	return len(parts) >= 2 && parts[0] != "" && parts[1] != "" && !strings.Contains(parts[0], ".")
+}
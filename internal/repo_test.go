@@ -0,0 +1,272 @@
+package internal
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// repoStub serves a GitHub repository "team/skills" whose default branch
+// "main" points at commit "c0ffee" and contains a skill under weather/.
+func repoStub(t *testing.T) *RepoProvider {
+	t.Helper()
+	blobs := map[string]string{
+		"b1": "---\nname: weather\n---\n",
+		"b2": "echo",
+		"b3": "docs",
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/team/skills", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"full_name":"team/skills","description":"team skills","html_url":"https://github.com/team/skills","default_branch":"main","owner":{"login":"team"}}`))
+	})
+	mux.HandleFunc("/repos/team/skills/commits/", func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/repos/team/skills/commits/") {
+		case "main", "c0ffee":
+			w.Write([]byte(`{"sha":"c0ffee"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"No commit found"}`))
+		}
+	})
+	mux.HandleFunc("/repos/team/skills/git/trees/c0ffee", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tree":[
+			{"path":"README.md","type":"blob","sha":"b3"},
+			{"path":"weather","type":"tree","sha":"t1"},
+			{"path":"weather/SKILL.md","type":"blob","sha":"b1"},
+			{"path":"weather/scripts/fetch.sh","type":"blob","sha":"b2"},
+			{"path":"weather/.env","type":"blob","sha":"b3"}
+		]}`))
+	})
+	mux.HandleFunc("/repos/team/skills/git/blobs/", func(w http.ResponseWriter, r *http.Request) {
+		content, ok := blobs[strings.TrimPrefix(r.URL.Path, "/repos/team/skills/git/blobs/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte(content)))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return &RepoProvider{Client: &GitHubClient{BaseURL: srv.URL}}
+}
+
+func TestRepoProvider_FetchSnippet(t *testing.T) {
+	p := repoStub(t)
+	for _, id := range []string{"team/skills", "team/skills/weather", "team/skills/weather@main"} {
+		g, err := p.FetchSnippet(id)
+		if err != nil {
+			t.Fatalf("FetchSnippet(%q) error: %v", id, err)
+		}
+		if g.Revision() != "c0ffee" || g.Owner.Login != "team" || g.Repo != "team/skills" || g.RepoPath != "weather" {
+			t.Errorf("FetchSnippet(%q) = %+v", id, g)
+		}
+		if len(g.Files) != 2 || g.Files["scripts/fetch.sh"].Content != "echo" {
+			t.Errorf("FetchSnippet(%q) files = %v, want SKILL.md and scripts/fetch.sh", id, g.Files)
+		}
+	}
+
+	if _, err := p.FetchSnippetRevision("team/skills", "nope"); err == nil {
+		t.Error("FetchSnippetRevision(unknown ref) = nil error")
+	}
+}
+
+func TestRepoProvider_FetchSnippetTruncatedTree(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/big/mono", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"full_name":"big/mono","default_branch":"main","owner":{"login":"big"}}`))
+	})
+	mux.HandleFunc("/repos/big/mono/commits/main", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sha":"c0ffee"}`))
+	})
+	mux.HandleFunc("/repos/big/mono/git/trees/", func(w http.ResponseWriter, r *http.Request) {
+		recursive := r.URL.Query().Get("recursive") != ""
+		switch strings.TrimPrefix(r.URL.Path, "/repos/big/mono/git/trees/") + fmt.Sprint(recursive) {
+		case "c0ffeetrue":
+			w.Write([]byte(`{"tree":[{"path":"skills/weather/SKILL.md","type":"blob","sha":"b1"}],"truncated":true}`))
+		case "c0ffeefalse":
+			w.Write([]byte(`{"tree":[{"path":"skills","type":"tree","sha":"t1"}]}`))
+		case "t1false":
+			w.Write([]byte(`{"tree":[{"path":"weather","type":"tree","sha":"t2"}]}`))
+		case "t2true":
+			w.Write([]byte(`{"tree":[{"path":"SKILL.md","type":"blob","sha":"b1"},{"path":"scripts","type":"tree","sha":"t3"},{"path":"scripts/fetch.sh","type":"blob","sha":"b2"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/repos/big/mono/git/blobs/", func(w http.ResponseWriter, r *http.Request) {
+		content := map[string]string{"b1": "---\nname: weather\n---\n", "b2": "echo"}[strings.TrimPrefix(r.URL.Path, "/repos/big/mono/git/blobs/")]
+		fmt.Fprintf(w, `{"content":%q,"encoding":"base64"}`, base64.StdEncoding.EncodeToString([]byte(content)))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	p := &RepoProvider{Client: &GitHubClient{BaseURL: srv.URL}}
+
+	g, err := p.FetchSnippet("big/mono/skills/weather")
+	if err != nil {
+		t.Fatalf("FetchSnippet() error: %v", err)
+	}
+	if len(g.Files) != 2 || g.Files["scripts/fetch.sh"].Content != "echo" {
+		t.Errorf("files = %v, want SKILL.md and scripts/fetch.sh from the subtree", g.Files)
+	}
+	if _, err := p.FetchSnippet("big/mono"); err == nil {
+		t.Error("FetchSnippet() of a truncated repository without a path = nil error")
+	}
+	if _, err := p.FetchSnippet("big/mono/skills/missing"); err == nil {
+		t.Error("FetchSnippet() of a missing directory = nil error")
+	}
+}
+
+func TestRepoProvider_LatestRevision(t *testing.T) {
+	p := repoStub(t)
+	sha, err := p.LatestRevision("team/skills/weather")
+	if err != nil {
+		t.Fatalf("LatestRevision() error: %v", err)
+	}
+	if sha != "c0ffee" {
+		t.Errorf("LatestRevision() = %q, want c0ffee", sha)
+	}
+}
+
+func TestInstallSkill_RepoPaths(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	g, err := repoStub(t).FetchSnippet("team/skills")
+	if err != nil {
+		t.Fatalf("FetchSnippet() error: %v", err)
+	}
+	g.Files["notes--draft.md"] = GistFile{Filename: "notes--draft.md", Content: "draft"}
+
+	meta, err := InstallSkill(g, &RepoProvider{})
+	if err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	if meta.Provider != "repo" || meta.Repo != "team/skills" || meta.Path != "weather" || meta.CommitSHA != "c0ffee" {
+		t.Errorf("InstallSkill() meta = %+v", meta)
+	}
+	dir := filepath.Join(SkillsBasePath(), "weather")
+	for _, rel := range []string{"SKILL.md", "scripts/fetch.sh", "notes--draft.md"} {
+		if _, err := os.Stat(filepath.Join(dir, rel)); err != nil {
+			t.Errorf("%s not installed: %v", rel, err)
+		}
+	}
+}
+
+func TestGitProvider_FetchSnippet(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	remote := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = remote
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	os.MkdirAll(filepath.Join(remote, "skills", "weather", "scripts"), 0755)
+	os.WriteFile(filepath.Join(remote, "skills", "weather", "SKILL.md"), []byte("---\nname: weather\n---\n"), 0644)
+	os.WriteFile(filepath.Join(remote, "skills", "weather", "scripts", "fetch.sh"), []byte("echo"), 0644)
+	run("init", "--quiet")
+	run("add", ".")
+	run("commit", "--quiet", "-m", "init")
+	run("tag", "v1")
+
+	p := &GitProvider{}
+	g, err := p.FetchSnippet(remote + "@v1")
+	if err != nil {
+		t.Fatalf("FetchSnippet() error: %v", err)
+	}
+	if g.RepoPath != "skills/weather" || g.Files["scripts/fetch.sh"].Content != "echo" {
+		t.Errorf("FetchSnippet() = %+v", g)
+	}
+	latest, err := p.LatestRevision(remote + "//skills/weather@v1")
+	if err != nil {
+		t.Fatalf("LatestRevision() error: %v", err)
+	}
+	if latest != g.Revision() {
+		t.Errorf("LatestRevision() = %q, want %q", latest, g.Revision())
+	}
+
+	// Refs whose names merely end in the branch name must not match it
+	run("branch", "stable")
+	run("commit", "--quiet", "--allow-empty", "-m", "later")
+	run("tag", "old/stable")
+	if latest, err := p.LatestRevision(remote + "@stable"); err != nil || latest != g.Revision() {
+		t.Errorf("LatestRevision(@stable) = %q, %v, want %q", latest, err, g.Revision())
+	}
+}
+
+func TestLocateSkillDir(t *testing.T) {
+	tests := []struct {
+		name    string
+		paths   []string
+		dir     string
+		wantDir string
+		want    int
+		wantErr bool
+	}{
+		{"root wins", []string{"SKILL.md", "a/SKILL.md", "notes.md"}, "", "", 3, false},
+		{"single nested", []string{"README.md", "a/SKILL.md", "a/x.sh"}, "", "a", 2, false},
+		{"skill.md suffix", []string{"b/weather.skill.md"}, "", "b", 1, false},
+		{"ambiguous", []string{"a/SKILL.md", "b/SKILL.md"}, "", "", 0, true},
+		{"none", []string{"README.md"}, "", "", 0, true},
+		{"explicit", []string{"a/SKILL.md", "b/SKILL.md", "b/.hidden"}, "b/", "b", 1, false},
+		{"empty dir", []string{"a/SKILL.md"}, "c", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, files, err := locateSkillDir(tt.paths, tt.dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("locateSkillDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (dir != tt.wantDir || len(files) != tt.want) {
+				t.Errorf("locateSkillDir() = (%q, %v), want (%q, %d files)", dir, files, tt.wantDir, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectProvider_Repositories(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	tests := []struct {
+		input        string
+		wantProvider string
+		wantID       string
+	}{
+		{"team/skills", "repo", "team/skills"},
+		{"team/skills/weather@v1", "repo", "team/skills/weather@v1"},
+		{"https://github.com/team/skills", "repo", "team/skills"},
+		{"https://github.com/team/skills.git", "repo", "team/skills"},
+		{"https://github.com/team/skills/tree/main/weather", "repo", "team/skills/weather@main"},
+		{"https://github.com/team/skills/blob/v1/weather/SKILL.md", "repo", "team/skills/weather@v1"},
+		{"git@github.com:team/skills.git", "repo", "team/skills"},
+		{"https://git.example.com/team/skills.git//weather", "git", "https://git.example.com/team/skills.git//weather"},
+		{"ssh://git@git.example.com/team/skills", "git", "ssh://git@git.example.com/team/skills"},
+		{"https://gist.github.com/nico/abc123", "github", "abc123"},
+	}
+	for _, tt := range tests {
+		provider, id := DetectProvider(tt.input)
+		if provider.Name() != tt.wantProvider || id != tt.wantID {
+			t.Errorf("DetectProvider(%q) = (%s, %q), want (%s, %q)", tt.input, provider.Name(), id, tt.wantProvider, tt.wantID)
+		}
+	}
+}
+
+func TestSplitGitID(t *testing.T) {
+	remote, dir, ref := splitGitID("https://git.example.com/team/skills.git//weather/@v1")
+	if remote != "https://git.example.com/team/skills.git" || dir != "weather" || ref != "v1" {
+		t.Errorf("splitGitID() = (%q, %q, %q)", remote, dir, ref)
+	}
+	remote, dir, ref = splitGitID("git@example.com:team/skills.git")
+	if remote != "git@example.com:team/skills.git" || dir != "" || ref != "" {
+		t.Errorf("splitGitID(scp) = (%q, %q, %q)", remote, dir, ref)
+	}
+}
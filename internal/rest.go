@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Errors matched by *APIError via errors.Is.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// APIError is a non-2xx response from a REST API.
+type APIError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Message    string
+	// RateLimitReset is set when the request was rejected by rate limiting.
+	RateLimitReset time.Time
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if !e.RateLimitReset.IsZero() {
+		return fmt.Sprintf("%s %s: rate limited until %s (HTTP %d)", e.Method, e.URL, e.RateLimitReset.Local().Format(time.Kitchen), e.StatusCode)
+	}
+	return fmt.Sprintf("%s %s: %s (HTTP %d)", e.Method, e.URL, msg, e.StatusCode)
+}
+
+// Is lets callers match API errors with errors.Is(err, ErrNotFound) etc.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrRateLimited:
+		return !e.RateLimitReset.IsZero() || e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// doRequest sends an HTTP request, encoding body as JSON if non-nil, and
+// returns the response body. Non-2xx responses become *APIError.
+func doRequest(client *http.Client, method, url string, header http.Header, body interface{}) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("User-Agent", "gh-skill")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, url, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{Method: method, URL: url, StatusCode: resp.StatusCode}
+		var msg struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &msg) == nil {
+			apiErr.Message = msg.Message
+		}
+		if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+			if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+				if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+					apiErr.RateLimitReset = time.Unix(reset, 0)
+				}
+			}
+		}
+		return nil, apiErr
+	}
+	return data, nil
+}
+
+// doJSON is doRequest followed by decoding the response into out if non-nil.
+func doJSON(client *http.Client, method, url string, header http.Header, body, out interface{}) error {
+	data, err := doRequest(client, method, url, header, body)
+	if err != nil {
+		return err
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("failed to parse response from %s: %w", url, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImportedRule is a rule read from another tool's instruction files, ready to
+// become a skill.
+type ImportedRule struct {
+	Name        string
+	Description string
+	Body        string
+	Source      string // file, and section if the file held several rules
+}
+
+// ruleFrontMatter holds the fields Cursor rules and Copilot instruction
+// files put in their front matter.
+type ruleFrontMatter struct {
+	Description string      `yaml:"description"`
+	Globs       interface{} `yaml:"globs"`
+	AlwaysApply bool        `yaml:"alwaysApply"`
+	ApplyTo     string      `yaml:"applyTo"`
+}
+
+// IsRuleFile reports whether a file name is one import-rules understands:
+// Cursor .mdc rules, CLAUDE.md, AGENTS.md and Copilot instruction files.
+func IsRuleFile(name string) bool {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, ".mdc"), strings.HasSuffix(lower, ".instructions.md"):
+		return true
+	}
+	switch lower {
+	case "claude.md", "agents.md", "copilot-instructions.md", ".cursorrules":
+		return true
+	}
+	return false
+}
+
+// FindRuleFiles returns the rule files at path: path itself if it is a file,
+// or every rule file below it if it is a directory.
+func FindRuleFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	err = filepath.Walk(path, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() && p != path && (fi.Name() == ".git" || fi.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+		if !fi.IsDir() && IsRuleFile(fi.Name()) {
+			files = append(files, p)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+// ParseRuleFile reads the rules in a rule file. A Cursor rule or Copilot
+// instruction file is one rule; CLAUDE.md, AGENTS.md and
+// copilot-instructions.md give one rule per ## section, or one for the whole
+// file if it has no sections. Sections gh skill rendered itself are skipped.
+func ParseRuleFile(path string) ([]ImportedRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	base := filepath.Base(path)
+	lower := strings.ToLower(base)
+
+	var fm ruleFrontMatter
+	if yamlText, ok := frontMatterText(content); ok {
+		if err := yaml.Unmarshal([]byte(yamlText), &fm); err != nil {
+			return nil, fmt.Errorf("%s: invalid front matter: %w", path, err)
+		}
+		content = SkillBody(content)
+	}
+
+	switch {
+	case strings.HasSuffix(lower, ".mdc"), strings.HasSuffix(lower, ".instructions.md"):
+		name := strings.TrimSuffix(strings.TrimSuffix(lower, ".mdc"), ".instructions.md")
+		rule := ImportedRule{
+			Name:        RuleSkillName(name),
+			Description: strings.TrimSpace(fm.Description),
+			Body:        strings.TrimSpace(content),
+			Source:      path,
+		}
+		if rule.Description == "" {
+			rule.Description = firstParagraph(rule.Body)
+		}
+		if scope := ruleScope(fm); scope != "" {
+			heading := ""
+			if strings.HasPrefix(rule.Body, "# ") {
+				heading, rule.Body, _ = strings.Cut(rule.Body, "\n")
+				heading += "\n\n"
+			}
+			rule.Body = heading + scope + "\n\n" + strings.TrimSpace(rule.Body)
+		}
+		return []ImportedRule{rule}, nil
+	}
+
+	content = stripRenderedSections(content)
+	preamble, sections := splitSections(content)
+	fileName := strings.TrimSuffix(lower, ".md")
+	if lower == ".cursorrules" {
+		fileName = "cursorrules"
+	}
+	if dir := filepath.Base(filepath.Dir(path)); dir != "." && dir != ".github" && dir != string(filepath.Separator) {
+		fileName = dir + "-" + fileName
+	}
+
+	var rules []ImportedRule
+	if len(sections) == 0 || hasContent(preamble) {
+		body := strings.TrimSpace(preamble)
+		if len(sections) == 0 {
+			body = strings.TrimSpace(content)
+		}
+		if body != "" {
+			rules = append(rules, ImportedRule{
+				Name:        RuleSkillName(fileName),
+				Description: firstParagraph(body),
+				Body:        body,
+				Source:      path,
+			})
+		}
+	}
+	for _, s := range sections {
+		body := strings.TrimSpace(s.body)
+		if body == "" {
+			continue
+		}
+		rules = append(rules, ImportedRule{
+			Name:        RuleSkillName(s.title),
+			Description: firstParagraph(body),
+			Body:        "# " + s.title + "\n\n" + body,
+			Source:      path + "#" + s.title,
+		})
+	}
+	return rules, nil
+}
+
+// RuleSkillFiles returns the skill folder for a rule: a SKILL.md with
+// generated front matter.
+func RuleSkillFiles(r ImportedRule) map[string]string {
+	description := r.Description
+	if description == "" {
+		description = "Rules imported from " + filepath.Base(r.Source)
+	}
+	front, _ := yaml.Marshal(struct {
+		Name        string `yaml:"name"`
+		Description string `yaml:"description"`
+		Version     string `yaml:"version"`
+	}{r.Name, description, "0.1.0"})
+
+	body := strings.TrimSpace(r.Body)
+	if !strings.HasPrefix(body, "# ") {
+		body = "# " + NewSkillTemplateData(r.Name, "").Title + "\n\n" + body
+	}
+	return map[string]string{"SKILL.md": "---\n" + string(front) + "---\n\n" + body + "\n"}
+}
+
+var nonNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// RuleSkillName turns a file or heading name into a skill name.
+func RuleSkillName(s string) string {
+	name := strings.Trim(nonNameChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if len(name) > maxSkillNameLength {
+		name = strings.TrimRight(name[:maxSkillNameLength], "-")
+	}
+	if name == "" {
+		name = "rules"
+	}
+	return name
+}
+
+// ruleScope describes when a Cursor or Copilot rule applies, which a skill
+// leaves to the agent.
+func ruleScope(fm ruleFrontMatter) string {
+	var globs []string
+	switch g := fm.Globs.(type) {
+	case string:
+		for _, s := range strings.Split(g, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				globs = append(globs, s)
+			}
+		}
+	case []interface{}:
+		for _, s := range g {
+			globs = append(globs, fmt.Sprint(s))
+		}
+	}
+	if fm.ApplyTo != "" {
+		globs = append(globs, fm.ApplyTo)
+	}
+	switch {
+	case len(globs) > 0:
+		return "Apply these rules when working on files matching `" + strings.Join(globs, "`, `") + "`."
+	case fm.AlwaysApply:
+		return "Apply these rules to every task in the project."
+	}
+	return ""
+}
+
+func frontMatterText(content string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	if strings.TrimSpace(lines[0]) != "---" {
+		return "", false
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return strings.Join(lines[1:i], "\n"), true
+		}
+	}
+	return "", false
+}
+
+type ruleSection struct {
+	title string
+	body  string
+}
+
+// splitSections splits Markdown at its ## headings, outside code fences.
+func splitSections(content string) (preamble string, sections []ruleSection) {
+	var cur *ruleSection
+	var pre []string
+	var body []string
+	fence := false
+	flush := func() {
+		if cur != nil {
+			cur.body = strings.Join(body, "\n")
+			sections = append(sections, *cur)
+		}
+		body = nil
+	}
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = !fence
+		}
+		if !fence && strings.HasPrefix(line, "## ") {
+			flush()
+			cur = &ruleSection{title: strings.TrimSpace(strings.TrimPrefix(line, "## "))}
+			continue
+		}
+		if cur == nil {
+			pre = append(pre, line)
+		} else {
+			body = append(body, unnestHeading(line, fence))
+		}
+	}
+	flush()
+	return strings.Join(pre, "\n"), sections
+}
+
+// unnestHeading moves a heading inside a ## section one level up, so the
+// section's ### subsections become the skill's ## sections.
+func unnestHeading(line string, fence bool) string {
+	if !fence && strings.HasPrefix(line, "###") {
+		return line[1:]
+	}
+	return line
+}
+
+// hasContent reports whether Markdown has more than headings and blank lines.
+func hasContent(md string) bool {
+	for _, line := range strings.Split(md, "\n") {
+		if t := strings.TrimSpace(line); t != "" && !strings.HasPrefix(t, "#") {
+			return true
+		}
+	}
+	return false
+}
+
+// firstParagraph returns the first paragraph of prose in Markdown, on one
+// line and cut to the description limit.
+func firstParagraph(md string) string {
+	var words []string
+	fence := false
+	for _, line := range strings.Split(md, "\n") {
+		t := strings.TrimSpace(line)
+		if strings.HasPrefix(t, "```") || strings.HasPrefix(t, "~~~") {
+			fence = !fence
+			continue
+		}
+		switch {
+		case fence, strings.HasPrefix(t, "#"), strings.HasPrefix(t, "<!--"):
+			continue
+		case t == "":
+			if len(words) > 0 {
+				return limitDescription(strings.Join(words, " "))
+			}
+			continue
+		}
+		t = strings.TrimLeft(t, "-*> ")
+		words = append(words, strings.Fields(t)...)
+	}
+	return limitDescription(strings.Join(words, " "))
+}
+
+func limitDescription(s string) string {
+	if len(s) <= maxDescriptionLength {
+		return s
+	}
+	s = s[:maxDescriptionLength-3]
+	if i := strings.LastIndex(s, " "); i > 0 {
+		s = s[:i]
+	}
+	return s + "..."
+}
+
+// stripRenderedSections drops the sections link rendered from skills, which
+// are skills already.
+func stripRenderedSections(content string) string {
+	for {
+		i := strings.Index(content, "<!-- gh-skill:begin ")
+		if i < 0 {
+			return content
+		}
+		fields := strings.Fields(content[i+len("<!-- gh-skill:begin "):])
+		if len(fields) == 0 {
+			return content
+		}
+		_, end := sectionMarkers(fields[0])
+		j := strings.Index(content[i:], end)
+		if j < 0 {
+			return content
+		}
+		content = content[:i] + strings.TrimPrefix(content[i+j+len(end):], "\n")
+	}
+}
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRuleSkillName(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"Go Style", "go-style"},
+		{"API: v2 (REST)", "api-v2-rest"},
+		{"--", "rules"},
+		{strings.Repeat("a", 70), strings.Repeat("a", 64)},
+	}
+	for _, tt := range tests {
+		if got := RuleSkillName(tt.in); got != tt.want {
+			t.Errorf("RuleSkillName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseRuleFile_CursorRule(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"Go Style.mdc": "---\ndescription: Go style rules\nglobs: \"**/*.go, go.mod\"\nalwaysApply: false\n---\n# Go\n\n- Wrap errors.\n",
+	})
+	rules, err := ParseRuleFile(filepath.Join(dir, "Go Style.mdc"))
+	if err != nil {
+		t.Fatalf("ParseRuleFile() error: %v", err)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("ParseRuleFile() = %d rules, want 1", len(rules))
+	}
+	r := rules[0]
+	if r.Name != "go-style" || r.Description != "Go style rules" {
+		t.Errorf("rule = %+v", r)
+	}
+	want := "# Go\n\nApply these rules when working on files matching `**/*.go`, `go.mod`.\n\n- Wrap errors."
+	if r.Body != want {
+		t.Errorf("Body = %q, want %q", r.Body, want)
+	}
+}
+
+func TestParseRuleFile_Sections(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"proj/CLAUDE.md": "# Project\n\nUse pnpm.\n\n## Testing\n\nRun tests first.\n\n### Flaky\n\nRetry once.\n\n```sh\n## not a section\n```\n\n" +
+			"<!-- gh-skill:begin demo -->\n## Skill: demo\n<!-- gh-skill:end demo -->\n\n## Empty\n",
+	})
+	rules, err := ParseRuleFile(filepath.Join(dir, "proj", "CLAUDE.md"))
+	if err != nil {
+		t.Fatalf("ParseRuleFile() error: %v", err)
+	}
+	var names []string
+	for _, r := range rules {
+		names = append(names, r.Name)
+	}
+	if strings.Join(names, ",") != "proj-claude,testing" {
+		t.Fatalf("rules = %v, want [proj-claude testing]", names)
+	}
+	r := rules[1]
+	if r.Description != "Run tests first." {
+		t.Errorf("Description = %q", r.Description)
+	}
+	if !strings.Contains(r.Body, "\n## Flaky\n") || !strings.Contains(r.Body, "## not a section") {
+		t.Errorf("Body = %q", r.Body)
+	}
+}
+
+func TestRuleSkillFiles(t *testing.T) {
+	files := RuleSkillFiles(ImportedRule{Name: "deploys", Description: "Never deploy: Fridays", Body: "Be careful.", Source: "AGENTS.md#Deploys"})
+	content := files["SKILL.md"]
+	fm, err := ParseFrontMatter(content)
+	if err != nil {
+		t.Fatalf("ParseFrontMatter() error: %v", err)
+	}
+	if fm.Name != "deploys" || fm.Description != "Never deploy: Fridays" || fm.Version != "0.1.0" {
+		t.Errorf("front matter = %+v", fm)
+	}
+	if !strings.Contains(content, "# Deploys\n\nBe careful.\n") {
+		t.Errorf("SKILL.md = %q", content)
+	}
+}
+
+func TestFindRuleFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		".cursor/rules/a.mdc":                     "a",
+		".github/copilot-instructions.md":         "b",
+		".github/instructions/ts.instructions.md": "c",
+		"AGENTS.md":                "d",
+		"README.md":                "e",
+		"node_modules/x/CLAUDE.md": "f",
+	})
+	files, err := FindRuleFiles(dir)
+	if err != nil {
+		t.Fatalf("FindRuleFiles() error: %v", err)
+	}
+	if len(files) != 4 {
+		t.Errorf("FindRuleFiles() = %v, want 4 files", files)
+	}
+}
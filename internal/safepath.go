@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// maxPathSegment is the longest file or directory name most filesystems accept.
+	maxPathSegment = 255
+	// maxPathLength bounds the full relative path of an installed file.
+	maxPathLength = 1024
+)
+
+// reservedNames may not be used as installed file names: Windows device
+// names, and the metadata file owned by gh-skill.
+var reservedNames = map[string]bool{
+	"con": true, "prn": true, "aux": true, "nul": true,
+	"com1": true, "com2": true, "com3": true, "com4": true, "com5": true,
+	"com6": true, "com7": true, "com8": true, "com9": true,
+	"lpt1": true, "lpt2": true, "lpt3": true, "lpt4": true, "lpt5": true,
+	"lpt6": true, "lpt7": true, "lpt8": true, "lpt9": true,
+	".gistskill.json": true,
+}
+
+// UnsafePathError reports a gist file or skill name that would not resolve to
+// a path safely inside the skill directory.
+type UnsafePathError struct {
+	Name   string
+	Reason string
+}
+
+func (e *UnsafePathError) Error() string {
+	return fmt.Sprintf("unsafe file name %q: %s", e.Name, e.Reason)
+}
+
+// InstallPath returns the slash-separated path, relative to the skill
+// directory, that a gist file is installed at. It applies the -- expansion,
+// drops the BinarySuffix and applies the <name>.skill.md → SKILL.md rename,
+// and returns an *UnsafePathError
+// for names that are absolute, contain . or .. segments, are reserved, or
+// are too long.
+func InstallPath(filename string) (string, error) {
+	return resolveInstallPath(filename, true)
+}
+
+// InstallPath resolves a file of this gist like the package-level
+// InstallPath, but treats names as real paths for repository-backed gists.
+func (g *Gist) InstallPath(filename string) (string, error) {
+	return resolveInstallPath(filename, !g.PathNames)
+}
+
+func resolveInstallPath(filename string, expand bool) (string, error) {
+	unsafe := func(reason string) error { return &UnsafePathError{Name: filename, Reason: reason} }
+
+	if filename == "" {
+		return "", unsafe("empty name")
+	}
+	if len(filename) > maxPathLength {
+		return "", unsafe("name is too long")
+	}
+	for _, r := range filename {
+		if r < 0x20 || r == 0x7f {
+			return "", unsafe("contains control characters")
+		}
+	}
+	if strings.ContainsAny(filename, `\:`) {
+		return "", unsafe("contains a backslash or drive separator")
+	}
+
+	rel := filename
+	if expand {
+		rel = filepath.ToSlash(ExpandFilename(strings.TrimSuffix(filename, BinarySuffix)))
+	}
+	if strings.HasPrefix(rel, "/") {
+		return "", unsafe("absolute path")
+	}
+	for _, seg := range strings.Split(rel, "/") {
+		switch {
+		case seg == "":
+			return "", unsafe("empty path segment")
+		case seg == "." || seg == "..":
+			return "", unsafe("contains a " + seg + " segment")
+		case len(seg) > maxPathSegment:
+			return "", unsafe("path segment is too long")
+		case reservedNames[strings.ToLower(strings.SplitN(seg, ".", 2)[0])] || reservedNames[strings.ToLower(seg)]:
+			return "", unsafe("reserved name " + seg)
+		}
+	}
+
+	// Only a top-level <name>.skill.md is the skill file in a repository
+	if IsSkillFile(rel) && (expand || !strings.Contains(rel, "/")) {
+		return "SKILL.md", nil
+	}
+	return rel, nil
+}
+
+// ValidateSkillName checks that a skill name can be used as a single
+// directory name under the skills base path.
+func ValidateSkillName(name string) error {
+	unsafe := func(reason string) error { return &UnsafePathError{Name: name, Reason: reason} }
+	switch {
+	case name == "":
+		return unsafe("empty skill name")
+	case len(name) > maxPathSegment:
+		return unsafe("skill name is too long")
+	case strings.HasPrefix(name, "."):
+		return unsafe("skill name starts with a dot")
+	case strings.ContainsAny(name, `/\:`):
+		return unsafe("skill name contains a path separator")
+	}
+	for _, r := range name {
+		if r < 0x20 || r == 0x7f {
+			return unsafe("contains control characters")
+		}
+	}
+	if reservedNames[strings.ToLower(strings.SplitN(name, ".", 2)[0])] {
+		return unsafe("reserved name")
+	}
+	return nil
+}
+
+// SafeJoin joins a relative install path onto base and refuses to pass
+// through any existing symlink, so writes cannot escape base.
+func SafeJoin(base, rel string) (string, error) {
+	dest := filepath.Join(base, filepath.FromSlash(rel))
+	if r, err := filepath.Rel(base, dest); err != nil || r == ".." || strings.HasPrefix(r, ".."+string(os.PathSeparator)) {
+		return "", &UnsafePathError{Name: rel, Reason: "escapes the skill directory"}
+	}
+	cur := base
+	for _, seg := range strings.Split(rel, "/") {
+		cur = filepath.Join(cur, seg)
+		info, err := os.Lstat(cur)
+		if err != nil {
+			break // nothing exists below here yet
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", &UnsafePathError{Name: rel, Reason: "passes through a symlink"}
+		}
+	}
+	return dest, nil
+}
+
+// CheckGistFiles returns an error for every gist file that cannot be
+// installed safely: an *UnsafePathError for unsafe names, or a decoding
+// error for encoded binary files that are not valid base64.
+func CheckGistFiles(g *Gist) []error {
+	var errs []error
+	for filename := range g.Files {
+		if _, err := g.InstallPath(filename); err != nil {
+			errs = append(errs, err)
+		} else if _, err := g.FileContent(filename); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallPath(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"SKILL.md", "SKILL.md", false},
+		{"weather.skill.md", "SKILL.md", false},
+		{"scripts--setup.sh", "scripts/setup.sh", false},
+		{"..--..--.bashrc", "", true},
+		{"scripts--..--..--x", "", true},
+		{"--etc--passwd", "", true},
+		{"a----b", "", true},
+		{".gistskill.json", "", true},
+		{"refs--.gistskill.json", "", true},
+		{"CON.txt", "", true},
+		{`..\evil`, "", true},
+		{"C:--evil", "", true},
+		{"bad\x00name", "", true},
+		{strings.Repeat("a", 300), "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		got, err := InstallPath(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("InstallPath(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if err != nil {
+			var upe *UnsafePathError
+			if !errors.As(err, &upe) {
+				t.Errorf("InstallPath(%q) error type = %T, want *UnsafePathError", tt.input, err)
+			}
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("InstallPath(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestValidateSkillName(t *testing.T) {
+	for _, name := range []string{"weather", "my-skill_2"} {
+		if err := ValidateSkillName(name); err != nil {
+			t.Errorf("ValidateSkillName(%q) = %v, want nil", name, err)
+		}
+	}
+	for _, name := range []string{"", "..", ".history", "../x", `a\b`, "nul"} {
+		if err := ValidateSkillName(name); err == nil {
+			t.Errorf("ValidateSkillName(%q) = nil, want error", name)
+		}
+	}
+}
+
+func TestSafeJoin_Symlink(t *testing.T) {
+	base := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Symlink(outside, filepath.Join(base, "scripts")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	if _, err := SafeJoin(base, "scripts/setup.sh"); err == nil {
+		t.Error("SafeJoin through symlink = nil error, want error")
+	}
+	got, err := SafeJoin(base, "references/api.md")
+	if err != nil {
+		t.Fatalf("SafeJoin() error: %v", err)
+	}
+	if want := filepath.Join(base, "references", "api.md"); got != want {
+		t.Errorf("SafeJoin() = %q, want %q", got, want)
+	}
+}
+
+func TestInstallSkill_RejectsTraversal(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	g := testGist("aaa111", "body")
+	g.Files["..--..--.bashrc"] = GistFile{Content: "evil"}
+	if _, err := InstallSkill(g); err == nil {
+		t.Fatal("InstallSkill() = nil error, want rejection")
+	}
+	if _, err := os.Stat(filepath.Join(SkillsBasePath(), "demo")); !os.IsNotExist(err) {
+		t.Errorf("skill directory was created despite rejection")
+	}
+}
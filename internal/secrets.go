@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// SecretFinding is a likely credential found in a file about to be published.
+type SecretFinding struct {
+	File string // path in the skill folder
+	Line int    // 1-based; 0 when the whole file is the finding
+	Kind string
+}
+
+func (f SecretFinding) String() string {
+	if f.Line == 0 {
+		return fmt.Sprintf("%s: %s", f.File, f.Kind)
+	}
+	return fmt.Sprintf("%s:%d: %s", f.File, f.Line, f.Kind)
+}
+
+var secretPatterns = []struct {
+	kind string
+	re   *regexp.Regexp
+}{
+	{"GitHub token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"GitLab token", regexp.MustCompile(`\bgl(pat|dt|rt|ptt|cbt)-[A-Za-z0-9_\-]{20,}`)},
+	{"AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"AWS secret access key", regexp.MustCompile(`(?i)aws_?secret_?(access_?)?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`)},
+	{"Slack token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"private key block", regexp.MustCompile(`-----BEGIN ([A-Z0-9]+ )*PRIVATE KEY( BLOCK)?-----`)},
+}
+
+// envAssignment matches KEY=value and key: "value" lines; scanLine only
+// reports them when the key names a credential.
+var envAssignment = regexp.MustCompile(`^\s*(?:export\s+)?([A-Za-z_][A-Za-z0-9_]*)\s*[:=]\s*(["']?)([^\s"'#]+)`)
+
+var secretKeyWords = []string{"SECRET", "TOKEN", "PASSWORD", "PASSWD", "API_KEY", "APIKEY", "PRIVATE_KEY", "ACCESS_KEY", "CREDENTIAL"}
+
+// entropyCandidate matches long runs of base64/url-safe characters.
+var entropyCandidate = regexp.MustCompile(`[A-Za-z0-9+/_\-]{32,}={0,2}`)
+
+// secretFileNames are files that are credentials by name alone.
+var secretFileNames = []string{"id_rsa", "id_dsa", "id_ecdsa", "id_ed25519", "*.pem", "*.key", "*.p12", "*.pfx", "credentials.json", "*.keystore"}
+
+// ScanSecrets looks for likely credentials in files: provider tokens, AWS
+// keys, private key blocks, credential assignments in .env or shell style and
+// high-entropy strings. Findings are reported per line, in file order. The
+// content of binary files is not scanned, only their names.
+func ScanSecrets(files []PublishFile) []SecretFinding {
+	var findings []SecretFinding
+	for _, f := range files {
+		base := path.Base(f.Path)
+		for _, pattern := range secretFileNames {
+			if ok, _ := path.Match(pattern, base); ok {
+				findings = append(findings, SecretFinding{File: f.Path, Kind: "credential file"})
+				break
+			}
+		}
+		if f.Binary {
+			continue // encoded content would only yield high-entropy noise
+		}
+		for i, line := range strings.Split(f.Content, "\n") {
+			if kind := scanLine(line); kind != "" {
+				findings = append(findings, SecretFinding{File: f.Path, Line: i + 1, Kind: kind})
+			}
+		}
+	}
+	return findings
+}
+
+// scanLine returns the kind of the first likely secret on line, or "".
+func scanLine(line string) string {
+	for _, p := range secretPatterns {
+		if p.re.MatchString(line) {
+			return p.kind
+		}
+	}
+	// Unquoted values only count for env-style upper-case keys, which keeps
+	// code such as `token = os.Getenv("TOKEN")` from being reported.
+	if m := envAssignment.FindStringSubmatch(line); m != nil && (m[2] != "" || m[1] == strings.ToUpper(m[1])) {
+		key := strings.ToUpper(m[1])
+		for _, word := range secretKeyWords {
+			if strings.Contains(key, word) && !isPlaceholder(m[3]) {
+				return "credential assignment (" + m[1] + ")"
+			}
+		}
+	}
+	for _, candidate := range entropyCandidate.FindAllString(line, -1) {
+		if looksRandom(candidate) {
+			return "high-entropy string"
+		}
+	}
+	return ""
+}
+
+// isPlaceholder reports whether an assigned value is clearly not a real
+// secret: too short, a variable reference or a documentation placeholder.
+func isPlaceholder(value string) bool {
+	if len(value) < 8 {
+		return true
+	}
+	if strings.ContainsAny(value[:1], "$<{[%") || strings.Contains(value, "(") {
+		return true
+	}
+	lower := strings.ToLower(value)
+	for _, p := range []string{"xxx", "changeme", "example", "your_", "your-", "placeholder", "redacted", "***"} {
+		if strings.Contains(lower, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// looksRandom reports whether s has the character mix and Shannon entropy
+// of a generated key rather than a word, path or hex digest.
+func looksRandom(s string) bool {
+	var upper, lower, digit bool
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= '0' && r <= '9':
+			digit = true
+		}
+	}
+	if !upper || !lower || !digit {
+		return false // hex digests, words and identifiers
+	}
+	return shannonEntropy(s) >= 4.5
+}
+
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len(s))
+	h := 0.0
+	for _, c := range counts {
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
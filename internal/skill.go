@@ -3,6 +3,7 @@ package internal
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,16 +17,73 @@ const SkillsDir = ".gistskills"
 
 // SkillMeta is the .gistskill.json metadata stored alongside installed skills.
 type SkillMeta struct {
-	Name        string `json:"name"`
-	GistID      string `json:"gist_id"`
-	Provider    string `json:"provider,omitempty"`
-	CommitSHA   string `json:"commit_sha"`
-	Description string `json:"description"`
-	Version     string `json:"version"`
-	Author      string `json:"author"`
-	GistURL     string `json:"gist_url"`
-	InstalledAt string `json:"installed_at"`
-	UpdatedAt   string `json:"updated_at"`
+	Name        string          `json:"name"`
+	GistID      string          `json:"gist_id"`
+	Provider    string          `json:"provider,omitempty"`
+	Host        string          `json:"host,omitempty"`
+	Repo        string          `json:"repo,omitempty"`
+	Path        string          `json:"path,omitempty"`
+	CommitSHA   string          `json:"commit_sha"`
+	Pinned      bool            `json:"pinned,omitempty"`
+	Collections CollectionNames `json:"collection,omitempty"`
+	Description string          `json:"description"`
+	Version     string          `json:"version"`
+	Author      string          `json:"author"`
+	GistURL     string          `json:"gist_url"`
+	InstalledAt string          `json:"installed_at"`
+	UpdatedAt   string          `json:"updated_at"`
+	// Renders are files generated from the skill for tools that do not
+	// read skill folders (see NativeFormats).
+	Renders []NativeRender `json:"renders,omitempty"`
+}
+
+// CollectionNames lists the installed collections a skill belongs to. Older
+// metadata holds a single name, which still reads.
+type CollectionNames []string
+
+func (c *CollectionNames) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*c = nil
+		if name != "" {
+			*c = CollectionNames{name}
+		}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*c = names
+	return nil
+}
+
+// Has reports whether the collection name (case-insensitive) is listed.
+func (c CollectionNames) Has(name string) bool {
+	for _, n := range c {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add lists the collection name unless it already is.
+func (c *CollectionNames) Add(name string) {
+	if !c.Has(name) {
+		*c = append(*c, name)
+	}
+}
+
+// Remove drops the collection name.
+func (c *CollectionNames) Remove(name string) {
+	var kept CollectionNames
+	for _, n := range *c {
+		if !strings.EqualFold(n, name) {
+			kept = append(kept, n)
+		}
+	}
+	*c = kept
 }
 
 // EffectiveProvider returns the provider name, defaulting to "github".
@@ -46,12 +104,48 @@ type FrontMatter struct {
 	Author      string   `yaml:"author"`
 }
 
+// projectRoot is the repository whose skills are managed, set by
+// UseProjectScope. Empty means the user's skills in $HOME.
+var projectRoot string
+
+// UseProjectScope makes SkillsBasePath, DetectToolDirs and ToolDirByName
+// work on <root>/.gistskills and the project's tool directories instead of
+// the user's. An empty root switches back to the user scope.
+func UseProjectScope(root string) {
+	projectRoot = root
+}
+
+// ProjectScope returns the project root set by UseProjectScope, if any.
+func ProjectScope() (string, bool) {
+	return projectRoot, projectRoot != ""
+}
+
 // SkillsBasePath returns the base path for installed skills.
 func SkillsBasePath() string {
+	if projectRoot != "" {
+		return filepath.Join(projectRoot, SkillsDir)
+	}
+	return userBasePath()
+}
+
+// userBasePath returns ~/.gistskills, which holds the user's settings
+// (config, trusted authors, templates) whatever the scope.
+func userBasePath() string {
 	home, _ := os.UserHomeDir()
 	return filepath.Join(home, SkillsDir)
 }
 
+// projectGitignore keeps history and in-progress installs out of the
+// repository; the project's skills themselves are meant to be committed.
+const projectGitignore = "# Written by gh skill: commit the skills, not their history\n/.*\n!/.gitignore\n"
+
+func writeProjectGitignore() {
+	path := filepath.Join(SkillsBasePath(), ".gitignore")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		os.WriteFile(path, []byte(projectGitignore), 0644)
+	}
+}
+
 // ExpandFilename converts gist flat filenames to directory paths.
 // e.g., "scripts--setup.sh" → "scripts/setup.sh"
 func ExpandFilename(name string) string {
@@ -77,9 +171,9 @@ func SkillFileName(name string) string {
 // FindSkillFile finds the *.skill.md file in a gist's files map.
 // Falls back to SKILL.md for backward compatibility.
 func FindSkillFile(files map[string]GistFile) (string, GistFile, bool) {
-	// First look for *.skill.md
+	// First look for a top-level *.skill.md
 	for name, f := range files {
-		if IsSkillFile(name) {
+		if IsSkillFile(name) && !strings.Contains(name, "/") {
 			return name, f, true
 		}
 	}
@@ -120,11 +214,22 @@ func ParseFrontMatter(content string) (*FrontMatter, error) {
 	return &fm, nil
 }
 
-// InstallSkill installs a gist/snippet as a skill. Provider defaults to "github".
-func InstallSkill(g *Gist, providerName ...string) (*SkillMeta, error) {
-	pName := "github"
-	if len(providerName) > 0 && providerName[0] != "" {
-		pName = providerName[0]
+// InstallSkill installs a gist/snippet as a skill, recording the provider it
+// came from. Provider defaults to github.com.
+func InstallSkill(g *Gist, provider ...Provider) (*SkillMeta, error) {
+	var p Provider
+	if len(provider) > 0 {
+		p = provider[0]
+	}
+	return installSkill(g, "", p)
+}
+
+// installSkill installs g under name, or under the name its files give if
+// name is empty.
+func installSkill(g *Gist, name string, provider Provider) (*SkillMeta, error) {
+	pName, host := "github", ""
+	if provider != nil {
+		pName, host = provider.Name(), provider.Host()
 	}
 	// Find the skill file (*.skill.md or legacy SKILL.md)
 	skillFileName, skillFile, ok := FindSkillFile(g.Files)
@@ -138,48 +243,39 @@ func InstallSkill(g *Gist, providerName ...string) (*SkillMeta, error) {
 		return nil, err
 	}
 
-	// Determine skill name: front matter > filename > gist ID
-	name := fm.Name
 	if name == "" {
-		name = SkillNameFromFile(skillFileName)
+		name = skillName(g.ID, skillFileName, fm)
 	}
-	if name == "" {
-		name = g.ID
+	if err := ValidateSkillName(name); err != nil {
+		return nil, err
 	}
-
-	// Create skill directory
-	skillDir := filepath.Join(SkillsBasePath(), name)
-	if err := os.MkdirAll(skillDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create skill directory: %w", err)
+	if holdsUserTemplates(name) {
+		return nil, fmt.Errorf("%s holds your skill templates; move them to %s to install a skill called %q",
+			filepath.Join(userBasePath(), templatesDir), filepath.Join(userBasePath(), fallbackTemplatesDir), name)
 	}
-
-	// Write all files, expanding -- convention for subdirectories
-	// Rename <name>.skill.md → SKILL.md on install (tools expect SKILL.md)
-	for filename, file := range g.Files {
-		expanded := ExpandFilename(filename)
-		if IsSkillFile(expanded) {
-			expanded = "SKILL.md"
-		}
-		destPath := filepath.Join(skillDir, expanded)
-		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
-			return nil, fmt.Errorf("failed to create directory for %s: %w", expanded, err)
-		}
-		if err := os.WriteFile(destPath, []byte(file.Content), 0644); err != nil {
-			return nil, fmt.Errorf("failed to write %s: %w", expanded, err)
-		}
+	if errs := CheckGistFiles(g); len(errs) > 0 {
+		return nil, errors.Join(errs...)
 	}
 
-	// Build metadata
-	commitSHA := ""
-	if len(g.History) > 0 {
-		commitSHA = g.History[0].Version
+	// Recover from an interrupted install before looking at what is installed
+	cleanupStaging(name)
+
+	// Keep the revision being replaced so it can be rolled back to
+	existing, _ := GetSkill(name)
+	if existing != nil && existing.CommitSHA != g.Revision() {
+		if err := SnapshotSkill(name); err != nil {
+			return nil, err
+		}
 	}
 
 	meta := &SkillMeta{
 		Name:        name,
 		GistID:      g.ID,
 		Provider:    pName,
-		CommitSHA:   commitSHA,
+		Host:        host,
+		Repo:        g.Repo,
+		Path:        g.RepoPath,
+		CommitSHA:   g.Revision(),
 		Description: fm.Description,
 		Version:     fm.Version,
 		Author:      g.Owner.Login,
@@ -187,15 +283,232 @@ func InstallSkill(g *Gist, providerName ...string) (*SkillMeta, error) {
 		InstalledAt: time.Now().UTC().Format(time.RFC3339),
 		UpdatedAt:   time.Now().UTC().Format(time.RFC3339),
 	}
+	if existing != nil {
+		// Collection membership and rendered files survive updates of the skill itself
+		meta.Collections = existing.Collections
+		meta.Renders = existing.Renders
+	}
+
+	// Stage the new revision in a sibling directory so a failed or
+	// interrupted install leaves the previous revision untouched
+	if err := os.MkdirAll(SkillsBasePath(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create skills directory: %w", err)
+	}
+	if projectRoot != "" {
+		writeProjectGitignore()
+	}
+	stageDir, err := os.MkdirTemp(SkillsBasePath(), "."+name+".staging-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer os.RemoveAll(stageDir)
+	if err := os.Chmod(stageDir, 0755); err != nil {
+		return nil, err
+	}
+
+	// Write all files, expanding -- convention for subdirectories
+	// Rename <name>.skill.md → SKILL.md on install (tools expect SKILL.md)
+	files := GistInstallFiles(g)
+	for rel, content := range files {
+		destPath, err := SafeJoin(stageDir, rel)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		if err := os.WriteFile(destPath, []byte(content), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+	}
+	if err := WriteSkillMeta(stageDir, meta); err != nil {
+		return nil, err
+	}
+
+	// The staged tree must mirror the gist exactly before it replaces anything
+	staged, err := readSkillFiles(stageDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify staged files: %w", err)
+	}
+	if diffs := DiffHashes(hashFiles(files), hashFiles(staged)); len(diffs) > 0 {
+		return nil, fmt.Errorf("staged files do not match the gist: %v", diffs)
+	}
+	if _, ok := staged["SKILL.md"]; !ok {
+		return nil, fmt.Errorf("staged skill is missing SKILL.md")
+	}
+
+	if err := swapSkillDir(stageDir, filepath.Join(SkillsBasePath(), name)); err != nil {
+		return nil, err
+	}
+	// Best effort, like linking: the skill itself is installed either way
+	RefreshNativeRenders(meta)
 
-	// Write metadata
-	metaPath := filepath.Join(skillDir, ".gistskill.json")
+	return meta, nil
+}
+
+// swapSkillDir replaces skillDir with the fully prepared directory staged,
+// restoring the previous directory if the swap fails.
+func swapSkillDir(staged, skillDir string) error {
+	name := filepath.Base(skillDir)
+	var old string
+	if _, err := os.Stat(skillDir); err == nil {
+		tmp, err := os.MkdirTemp(filepath.Dir(skillDir), "."+name+".old-")
+		if err != nil {
+			return fmt.Errorf("failed to prepare swap: %w", err)
+		}
+		old = filepath.Join(tmp, name)
+		if err := os.Rename(skillDir, old); err != nil {
+			os.RemoveAll(tmp)
+			return fmt.Errorf("failed to move previous revision aside: %w", err)
+		}
+		defer os.RemoveAll(tmp)
+	}
+	if err := os.Rename(staged, skillDir); err != nil {
+		if old != "" {
+			os.Rename(old, skillDir)
+		}
+		return fmt.Errorf("failed to install skill directory: %w", err)
+	}
+	return nil
+}
+
+// cleanupStaging removes staging and swap directories left behind by an
+// interrupted install of the named skill. If the install stopped between the
+// two renames of swapSkillDir, the moved-aside directory is the only copy of
+// the previous revision: it is put back rather than deleted.
+func cleanupStaging(name string) {
+	base := SkillsBasePath()
+	skillDir := filepath.Join(base, name)
+	olds, _ := filepath.Glob(filepath.Join(base, "."+name+".old-*"))
+	if _, err := os.Stat(skillDir); os.IsNotExist(err) {
+		for _, old := range olds {
+			if os.Rename(filepath.Join(old, name), skillDir) == nil {
+				break
+			}
+		}
+	}
+	if _, err := os.Stat(skillDir); err == nil {
+		for _, old := range olds {
+			os.RemoveAll(old)
+		}
+	}
+	stagings, _ := filepath.Glob(filepath.Join(base, "."+name+".staging-*"))
+	for _, m := range stagings {
+		os.RemoveAll(m)
+	}
+}
+
+// defaultSkillName derives a skill name from a snippet ID when neither the
+// front matter nor the file name provide one. Path-like IDs (repositories,
+// project snippets) use their last segment.
+func defaultSkillName(id string) string {
+	id, _ = SplitRevision(id)
+	id = strings.TrimRight(id, "/")
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		return id[i+1:]
+	}
+	return id
+}
+
+// SkillName returns the name a gist installs under: the name in its front
+// matter, else its <name>.skill.md file name, else the last part of its ID.
+func SkillName(g *Gist) (string, error) {
+	skillFileName, skillFile, ok := FindSkillFile(g.Files)
+	if !ok {
+		return "", fmt.Errorf("gist does not contain a *.skill.md file")
+	}
+	fm, err := ParseFrontMatter(skillFile.Content)
+	if err != nil {
+		return "", err
+	}
+	return skillName(g.ID, skillFileName, fm), nil
+}
+
+func skillName(id, skillFileName string, fm *FrontMatter) string {
+	if fm.Name != "" {
+		return fm.Name
+	}
+	if name := SkillNameFromFile(skillFileName); name != "" {
+		return name
+	}
+	return defaultSkillName(id)
+}
+
+// SaveSkillMeta writes a skill's .gistskill.json metadata file.
+func SaveSkillMeta(meta *SkillMeta) error {
+	return WriteSkillMeta(filepath.Join(SkillsBasePath(), meta.Name), meta)
+}
+
+// WriteSkillMeta writes meta to the .gistskill.json file in dir.
+func WriteSkillMeta(dir string, meta *SkillMeta) error {
+	metaPath := filepath.Join(dir, ".gistskill.json")
 	metaData, _ := json.MarshalIndent(meta, "", "  ")
 	if err := os.WriteFile(metaPath, metaData, 0644); err != nil {
-		return nil, fmt.Errorf("failed to write metadata: %w", err)
+		return fmt.Errorf("failed to write metadata: %w", err)
 	}
+	return nil
+}
 
-	return meta, nil
+// ReadSkillMeta reads the .gistskill.json file in dir. A missing file is
+// reported with an error satisfying os.IsNotExist.
+func ReadSkillMeta(dir string) (*SkillMeta, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ".gistskill.json"))
+	if err != nil {
+		return nil, err
+	}
+	var meta SkillMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("invalid metadata in %s: %w", dir, err)
+	}
+	return &meta, nil
+}
+
+// GistInstallFiles maps each gist file to the slash-separated path it is
+// installed at, applying the -- expansion and the SKILL.md rename, with
+// encoded binary files decoded.
+func GistInstallFiles(g *Gist) map[string]string {
+	files := make(map[string]string, len(g.Files))
+	for filename := range g.Files {
+		rel, err := g.InstallPath(filename)
+		if err != nil {
+			continue // rejected by CheckGistFiles before install
+		}
+		content, err := g.FileContent(filename)
+		if err != nil {
+			continue // rejected by CheckGistFiles before install
+		}
+		files[rel] = content
+	}
+	return files
+}
+
+// InstalledFiles reads every file of an installed skill except its metadata,
+// keyed by slash-separated path relative to the skill directory.
+func InstalledFiles(name string) (map[string]string, error) {
+	return readSkillFiles(filepath.Join(SkillsBasePath(), name))
+}
+
+func readSkillFiles(skillDir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.Walk(skillDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() == ".gistskill.json" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(skillDir, path)
+		files[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
 }
 
 // ListSkills lists all installed skills.
@@ -211,7 +524,8 @@ func ListSkills() ([]SkillMeta, error) {
 
 	var skills []SkillMeta
 	for _, e := range entries {
-		if !e.IsDir() {
+		// Dot directories hold history and in-progress installs
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
 			continue
 		}
 		metaPath := filepath.Join(base, e.Name(), ".gistskill.json")
@@ -230,6 +544,9 @@ func ListSkills() ([]SkillMeta, error) {
 
 // GetSkill reads metadata for a single skill.
 func GetSkill(name string) (*SkillMeta, error) {
+	if err := ValidateSkillName(name); err != nil {
+		return nil, err
+	}
 	metaPath := filepath.Join(SkillsBasePath(), name, ".gistskill.json")
 	data, err := os.ReadFile(metaPath)
 	if err != nil {
@@ -244,19 +561,25 @@ func GetSkill(name string) (*SkillMeta, error) {
 
 // RemoveSkill removes an installed skill and its symlinks.
 func RemoveSkill(name string) error {
+	if err := ValidateSkillName(name); err != nil {
+		return err
+	}
 	skillDir := filepath.Join(SkillsBasePath(), name)
-	if _, err := os.Stat(skillDir); os.IsNotExist(err) {
+	if _, err := os.Stat(skillDir); os.IsNotExist(err) || holdsUserTemplates(name) {
 		return fmt.Errorf("skill %q not found", name)
 	}
 
+	if meta, err := GetSkill(name); err == nil {
+		RemoveNativeRenders(meta)
+	}
+
 	// Remove symlinks from tool directories
 	for _, dir := range DetectToolDirs() {
-		link := filepath.Join(dir, name)
-		target, err := os.Readlink(link)
-		if err == nil && strings.HasPrefix(target, SkillsBasePath()) {
+		if link := filepath.Join(dir, name); isManagedLink(link) {
 			os.Remove(link)
 		}
 	}
 
+	os.RemoveAll(skillHistoryPath(name))
 	return os.RemoveAll(skillDir)
 }
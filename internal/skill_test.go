@@ -1,6 +1,12 @@
 package internal
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
 
 func TestEffectiveProvider(t *testing.T) {
 	tests := []struct {
@@ -136,3 +142,154 @@ func TestFindSkillFile(t *testing.T) {
 		t.Errorf("fallback name = %q, want SKILL.md", name2)
 	}
 }
+
+func TestInstallSkill_ReplacesStaleFiles(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	first := testGist("aaa111", "first")
+	first.Files["scripts--old.sh"] = GistFile{Content: "echo old"}
+	if _, err := InstallSkill(first); err != nil {
+		t.Fatalf("InstallSkill(first) error: %v", err)
+	}
+	if _, err := InstallSkill(testGist("bbb222", "second")); err != nil {
+		t.Fatalf("InstallSkill(second) error: %v", err)
+	}
+
+	files, err := InstalledFiles("demo")
+	if err != nil {
+		t.Fatalf("InstalledFiles() error: %v", err)
+	}
+	if len(files) != 1 || files["SKILL.md"] == "" {
+		t.Errorf("InstalledFiles() = %v, want only SKILL.md", files)
+	}
+
+	// No staging directories are left behind
+	leftovers, _ := filepath.Glob(filepath.Join(SkillsBasePath(), ".demo.*"))
+	if len(leftovers) != 0 {
+		t.Errorf("leftover staging directories: %v", leftovers)
+	}
+	if _, err := os.Stat(filepath.Join(SkillsBasePath(), "demo", ".gistskill.json")); err != nil {
+		t.Errorf("metadata missing: %v", err)
+	}
+}
+
+func TestReadSkillMeta(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := ReadSkillMeta(dir); !os.IsNotExist(err) {
+		t.Fatalf("ReadSkillMeta() on empty dir error = %v, want not-exist", err)
+	}
+	want := &SkillMeta{Name: "demo", GistID: "abc123", Provider: "gitlab", CommitSHA: "sha1"}
+	if err := WriteSkillMeta(dir, want); err != nil {
+		t.Fatalf("WriteSkillMeta() error: %v", err)
+	}
+	got, err := ReadSkillMeta(dir)
+	if err != nil {
+		t.Fatalf("ReadSkillMeta() error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadSkillMeta() = %+v, want %+v", got, want)
+	}
+}
+
+func TestProjectScope(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, ".claude"), 0755)
+	UseProjectScope(root)
+	t.Cleanup(func() { UseProjectScope("") })
+
+	if _, err := InstallSkill(testGist("aaa111", "body")); err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, SkillsDir, "demo", "SKILL.md")); err != nil {
+		t.Fatalf("skill not installed in the project: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, SkillsDir, "demo")); !os.IsNotExist(err) {
+		t.Errorf("skill installed in $HOME too: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, SkillsDir, ".gitignore")); err != nil {
+		t.Errorf(".gitignore not written: %v", err)
+	}
+
+	linked := AutoLink("demo")
+	toolDir := filepath.Join(root, ".claude", "skills")
+	if len(linked) != 1 || linked[0] != toolDir {
+		t.Fatalf("AutoLink() = %v, want [%s]", linked, toolDir)
+	}
+	target, _ := os.Readlink(filepath.Join(toolDir, "demo"))
+	if target != filepath.Join("..", "..", SkillsDir, "demo") {
+		t.Errorf("link target = %q, want a relative path", target)
+	}
+	if names := ManagedLinks(toolDir); len(names) != 1 {
+		t.Errorf("ManagedLinks() = %v", names)
+	}
+	if _, err := ToolDirByName("copilot"); err == nil {
+		t.Error("expected an error for a tool without a project-level directory")
+	}
+
+	if err := RemoveSkill("demo"); err != nil {
+		t.Fatalf("RemoveSkill() error: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(toolDir, "demo")); !os.IsNotExist(err) {
+		t.Errorf("link not removed: %v", err)
+	}
+}
+
+func TestInstallSkill_RecoversInterruptedSwap(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if _, err := InstallSkill(testGist("aaa111", "first")); err != nil {
+		t.Fatalf("InstallSkill() error: %v", err)
+	}
+
+	// Simulate a crash after the live directory was moved aside
+	old := filepath.Join(SkillsBasePath(), ".demo.old-123")
+	os.MkdirAll(old, 0755)
+	if err := os.Rename(filepath.Join(SkillsBasePath(), "demo"), filepath.Join(old, "demo")); err != nil {
+		t.Fatal(err)
+	}
+	cleanupStaging("demo")
+	content, err := os.ReadFile(filepath.Join(SkillsBasePath(), "demo", "SKILL.md"))
+	if err != nil || !strings.HasSuffix(string(content), "first") {
+		t.Fatalf("previous revision not restored: %q, %v", content, err)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("swap directory left behind: %v", err)
+	}
+}
+
+func TestIsManagedLink(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	tools := t.TempDir()
+	os.Symlink(filepath.Join(SkillsBasePath(), "demo"), filepath.Join(tools, "demo"))
+	os.Symlink(filepath.Join(home, SkillsDir+"-backup", "demo"), filepath.Join(tools, "backup"))
+
+	if !isManagedLink(filepath.Join(tools, "demo")) {
+		t.Error("isManagedLink(demo) = false, want true")
+	}
+	if isManagedLink(filepath.Join(tools, "backup")) {
+		t.Error("isManagedLink(backup) = true for a link into a sibling directory")
+	}
+}
+
+func TestSkillName(t *testing.T) {
+	tests := []struct {
+		id    string
+		files map[string]GistFile
+		want  string
+	}{
+		{"abc123", map[string]GistFile{"x.skill.md": {Content: "---\nname: weather\n---\n"}}, "weather"},
+		{"abc123", map[string]GistFile{"pdf.skill.md": {Content: "# PDF\n"}}, "pdf"},
+		{"team/skills/weather@main", map[string]GistFile{"SKILL.md": {Content: "# Weather\n"}}, "weather"},
+	}
+	for _, tt := range tests {
+		got, err := SkillName(&Gist{ID: tt.id, Files: tt.files})
+		if err != nil || got != tt.want {
+			t.Errorf("SkillName(%s) = %q, %v, want %q", tt.id, got, err, tt.want)
+		}
+	}
+	if _, err := SkillName(&Gist{ID: "abc123", Files: map[string]GistFile{"README.md": {}}}); err == nil {
+		t.Error("SkillName() = nil error without a skill file")
+	}
+}
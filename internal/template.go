@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// TemplateSuffix marks template files that are rendered with text/template
+// when a skill is created from them. Other files are copied verbatim.
+const TemplateSuffix = ".tmpl"
+
+// templatesDir holds the user's templates next to the installed skills. A
+// skill may only take its name while it holds no templates; the dot
+// directory is used instead when such a skill is installed.
+const (
+	templatesDir         = "templates"
+	fallbackTemplatesDir = ".templates"
+)
+
+// TemplatesPath returns the directory holding user skill templates, one
+// folder per template: ~/.gistskills/templates/<name>/, or
+// ~/.gistskills/.templates/<name>/ if a skill called templates is installed.
+func TemplatesPath() string {
+	dir := filepath.Join(userBasePath(), templatesDir)
+	if _, err := os.Stat(filepath.Join(dir, ".gistskill.json")); err == nil {
+		return filepath.Join(userBasePath(), fallbackTemplatesDir)
+	}
+	return dir
+}
+
+// holdsUserTemplates reports whether the skill directory name is the user's
+// templates directory, which installing or removing a skill must not touch.
+func holdsUserTemplates(name string) bool {
+	if name != templatesDir || SkillsBasePath() != userBasePath() {
+		return false
+	}
+	dir := filepath.Join(userBasePath(), templatesDir)
+	if _, err := os.Stat(dir); err != nil {
+		return false
+	}
+	_, err := os.Stat(filepath.Join(dir, ".gistskill.json"))
+	return err != nil
+}
+
+// SkillTemplateData is what template files can refer to.
+type SkillTemplateData struct {
+	Name        string // skill name, e.g. "pdf-tools"
+	Title       string // name as a heading, e.g. "Pdf Tools"
+	Description string
+}
+
+// NewSkillTemplateData returns the template data for a new skill.
+func NewSkillTemplateData(name, description string) SkillTemplateData {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + w[1:]
+	}
+	return SkillTemplateData{Name: name, Title: strings.Join(words, " "), Description: description}
+}
+
+// LoadUserTemplate reads the user template called name, keyed by
+// slash-separated path. ok is false if there is no such template.
+func LoadUserTemplate(name string) (files map[string]string, ok bool, err error) {
+	if err := ValidateSkillName(name); err != nil {
+		return nil, false, nil // not a template name; could be a gist reference
+	}
+	dir := filepath.Join(TemplatesPath(), name)
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, false, nil
+	}
+	files, err = readSkillFiles(dir)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read template %q: %w", name, err)
+	}
+	return files, true, nil
+}
+
+// UserTemplates lists the names of the user templates.
+func UserTemplates() []string {
+	entries, err := os.ReadDir(TemplatesPath())
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			names = append(names, e.Name())
+		}
+	}
+	return names
+}
+
+// RenderSkillTemplate renders the *.tmpl files of a template with data,
+// dropping the suffix, and sets the name in SKILL.md's front matter so
+// templates without placeholders (such as a published skill) work too.
+func RenderSkillTemplate(files map[string]string, data SkillTemplateData) (map[string]string, error) {
+	out := make(map[string]string, len(files))
+	for rel, content := range files {
+		if strings.HasSuffix(rel, TemplateSuffix) {
+			tmpl, err := template.New(rel).Option("missingkey=error").Parse(content)
+			if err != nil {
+				return nil, fmt.Errorf("invalid template %s: %w", rel, err)
+			}
+			var b bytes.Buffer
+			if err := tmpl.Execute(&b, data); err != nil {
+				return nil, fmt.Errorf("failed to render %s: %w", rel, err)
+			}
+			rel, content = strings.TrimSuffix(rel, TemplateSuffix), b.String()
+		}
+		out[rel] = content
+	}
+	skill, ok := out["SKILL.md"]
+	if !ok {
+		return nil, fmt.Errorf("template has no SKILL.md (or SKILL.md%s)", TemplateSuffix)
+	}
+	out["SKILL.md"] = SetFrontMatterField(skill, "name", data.Name)
+	return out, nil
+}
+
+// WriteSkillFolder creates dir with files, keyed by slash-separated path.
+// It refuses to write into a directory that already has files in it.
+func WriteSkillFolder(dir string, files map[string]string) error {
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return fmt.Errorf("%s already exists and is not empty", dir)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for rel, content := range files {
+		dest, err := SafeJoin(dir, rel)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		mode := os.FileMode(0644)
+		if strings.HasPrefix(content, "#!") {
+			mode = 0755
+		}
+		if err := os.WriteFile(dest, []byte(content), mode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", rel, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewSkillTemplateData(t *testing.T) {
+	data := NewSkillTemplateData("pdf-tools_v2", "Fills PDF forms")
+	if data.Title != "Pdf Tools V2" {
+		t.Errorf("Title = %q, want %q", data.Title, "Pdf Tools V2")
+	}
+}
+
+func TestRenderSkillTemplate(t *testing.T) {
+	files := map[string]string{
+		"SKILL.md.tmpl":   "---\nname: placeholder\ndescription: {{printf \"%q\" .Description}}\n---\n\n# {{.Title}}\n",
+		"scripts/run.sh":  "#!/bin/sh\necho {{.Name}}\n",
+		"references/a.md": "# A\n",
+	}
+	out, err := RenderSkillTemplate(files, NewSkillTemplateData("pdf-tools", "Fills: PDF forms"))
+	if err != nil {
+		t.Fatalf("RenderSkillTemplate() error: %v", err)
+	}
+	if _, ok := out["SKILL.md.tmpl"]; ok {
+		t.Error("SKILL.md.tmpl should be renamed to SKILL.md")
+	}
+	fm, err := ParseFrontMatter(out["SKILL.md"])
+	if err != nil {
+		t.Fatalf("ParseFrontMatter() error: %v", err)
+	}
+	if fm.Name != "pdf-tools" || fm.Description != "Fills: PDF forms" {
+		t.Errorf("front matter = %+v", fm)
+	}
+	if !strings.Contains(out["SKILL.md"], "# Pdf Tools") {
+		t.Errorf("title not rendered:\n%s", out["SKILL.md"])
+	}
+	if out["scripts/run.sh"] != files["scripts/run.sh"] {
+		t.Error("files without .tmpl should be copied verbatim")
+	}
+}
+
+func TestRenderSkillTemplateErrors(t *testing.T) {
+	data := NewSkillTemplateData("demo", "d")
+	if _, err := RenderSkillTemplate(map[string]string{"README.md": "x"}, data); err == nil {
+		t.Error("expected an error for a template without SKILL.md")
+	}
+	if _, err := RenderSkillTemplate(map[string]string{"SKILL.md.tmpl": "{{.Nope}}"}, data); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestWriteSkillFolder(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "demo")
+	files := map[string]string{
+		"SKILL.md":       "---\nname: demo\n---\n",
+		"scripts/run.sh": "#!/bin/sh\necho hi\n",
+		".skillignore":   "*.log\n",
+	}
+	if err := WriteSkillFolder(dir, files); err != nil {
+		t.Fatalf("WriteSkillFolder() error: %v", err)
+	}
+	info, err := os.Stat(filepath.Join(dir, "scripts", "run.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("run.sh mode = %v, want executable", info.Mode())
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".skillignore")); err != nil {
+		t.Errorf(".skillignore not written: %v", err)
+	}
+
+	if err := WriteSkillFolder(dir, files); err == nil {
+		t.Error("expected an error writing into a non-empty directory")
+	}
+	if err := WriteSkillFolder(filepath.Join(t.TempDir(), "x"), map[string]string{"../escape.md": "x"}); err == nil {
+		t.Error("expected an error for a path escaping the folder")
+	}
+}
+
+func TestLoadUserTemplate(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if _, ok, err := LoadUserTemplate("mine"); ok || err != nil {
+		t.Fatalf("LoadUserTemplate() = %v, %v before the template exists", ok, err)
+	}
+	writeTestFiles(t, filepath.Join(TemplatesPath(), "mine"), map[string]string{
+		"SKILL.md.tmpl": "---\nname: {{.Name}}\n---\n",
+		".skillignore":  "dist/\n",
+	})
+	files, ok, err := LoadUserTemplate("mine")
+	if err != nil || !ok {
+		t.Fatalf("LoadUserTemplate() = %v, %v", ok, err)
+	}
+	if len(files) != 2 || files[".skillignore"] != "dist/\n" {
+		t.Errorf("files = %v", files)
+	}
+	if names := UserTemplates(); len(names) != 1 || names[0] != "mine" {
+		t.Errorf("UserTemplates() = %v", names)
+	}
+}
+
+func TestTemplatesPath_SkillNamedTemplates(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if want := filepath.Join(home, SkillsDir, "templates"); TemplatesPath() != want {
+		t.Errorf("TemplatesPath() = %s, want %s", TemplatesPath(), want)
+	}
+
+	// Templates in the directory keep a skill from taking its name
+	writeTestFiles(t, filepath.Join(TemplatesPath(), "mine"), map[string]string{"SKILL.md": "# Mine\n"})
+	g := &Gist{ID: "abc123", Files: map[string]GistFile{"templates.skill.md": {Content: "# T\n"}}, History: []GistRevision{{Version: "aaa111"}}}
+	if _, err := InstallSkill(g); err == nil {
+		t.Error("InstallSkill(templates) = nil error over user templates")
+	}
+	if err := RemoveSkill("templates"); err == nil {
+		t.Error("RemoveSkill(templates) = nil error for the templates directory")
+	}
+
+	// Without templates the skill installs, and templates move to the dot directory
+	os.RemoveAll(filepath.Join(home, SkillsDir, "templates"))
+	if _, err := InstallSkill(g); err != nil {
+		t.Fatalf("InstallSkill(templates) error: %v", err)
+	}
+	if want := filepath.Join(home, SkillsDir, ".templates"); TemplatesPath() != want {
+		t.Errorf("TemplatesPath() = %s, want %s", TemplatesPath(), want)
+	}
+}
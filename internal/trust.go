@@ -24,7 +24,7 @@ type TrustStore struct {
 }
 
 func trustStorePath() string {
-	return filepath.Join(SkillsBasePath(), trustedAuthorsFile)
+	return filepath.Join(userBasePath(), trustedAuthorsFile)
 }
 
 // LoadTrustStore reads the trusted authors file.
@@ -45,7 +45,7 @@ func LoadTrustStore() (*TrustStore, error) {
 
 // Save writes the trust store to disk.
 func (ts *TrustStore) Save() error {
-	if err := os.MkdirAll(SkillsBasePath(), 0755); err != nil {
+	if err := os.MkdirAll(userBasePath(), 0755); err != nil {
 		return err
 	}
 	data, _ := json.MarshalIndent(ts, "", "  ")
@@ -122,10 +122,16 @@ func PromptTrust(g *Gist, fm *FrontMatter) (string, error) {
 	// File list
 	fmt.Println("  Files:")
 	var scripts []string
+	var unsafe []error
 	for filename := range g.Files {
-		expanded := ExpandFilename(filename)
+		expanded, err := g.InstallPath(filename)
+		if err != nil {
+			unsafe = append(unsafe, err)
+			fmt.Printf("    %s ✗\n", filename)
+			continue
+		}
 		marker := ""
-		if IsScriptFile(filename) {
+		if IsScriptFile(expanded) {
 			marker = " ⚡"
 			scripts = append(scripts, expanded)
 		}
@@ -137,6 +143,14 @@ func PromptTrust(g *Gist, fm *FrontMatter) (string, error) {
 		fmt.Printf("  ⚠️  Contains %d script(s) — review before running\n", len(scripts))
 	}
 
+	if len(unsafe) > 0 {
+		fmt.Println()
+		fmt.Printf("  ⛔ %d file(s) have unsafe names and cannot be installed:\n", len(unsafe))
+		for _, err := range unsafe {
+			fmt.Printf("    %v\n", err)
+		}
+	}
+
 	// SKILL.md preview (first 20 lines after front matter)
 	if sf, ok := g.Files["SKILL.md"]; ok {
 		fmt.Println()
@@ -183,7 +197,15 @@ func PromptTrust(g *Gist, fm *FrontMatter) (string, error) {
 			// Show all file contents
 			fmt.Println()
 			for filename, file := range g.Files {
-				fmt.Printf("  ══ %s ══\n", ExpandFilename(filename))
+				shown, err := g.InstallPath(filename)
+				if err != nil {
+					shown = filename
+				}
+				fmt.Printf("  ══ %s ══\n", shown)
+				if content, err := g.FileContent(filename); err == nil && IsBinary([]byte(content)) {
+					fmt.Printf("  │ (binary file, %s)\n\n", FormatSize(int64(len(content))))
+					continue
+				}
 				for _, line := range strings.Split(file.Content, "\n") {
 					fmt.Printf("  │ %s\n", line)
 				}
@@ -197,3 +219,77 @@ func PromptTrust(g *Gist, fm *FrontMatter) (string, error) {
 		}
 	}
 }
+
+// PromptCollectionTrust shows a single trust gate for every member of a
+// collection. Members by authors in ts are marked as trusted.
+// Returns: "install", "trust-author" (trust every listed author), or "" (abort).
+func PromptCollectionTrust(c *Collection, members []*Gist, ts *TrustStore) (string, error) {
+	fmt.Println()
+	fmt.Println("╭─────────────────────────────────────────╮")
+	fmt.Println("│         ⚠️  Install Collection?          │")
+	fmt.Println("╰─────────────────────────────────────────╯")
+	fmt.Printf("  Collection: %s\n", c.Name)
+	if c.Description != "" {
+		fmt.Printf("  %s\n", c.Description)
+	}
+	fmt.Println()
+
+	var scripts, unsafe int
+	for _, g := range members {
+		name := g.ID
+		if _, sf, ok := FindSkillFile(g.Files); ok {
+			if fm, err := ParseFrontMatter(sf.Content); err == nil && fm.Name != "" {
+				name = fm.Name
+			}
+		}
+		marker := ""
+		if ts.IsTrusted(g.Owner.Login) {
+			marker = " (trusted)"
+		}
+		fmt.Printf("  • %s by %s%s\n", name, g.Owner.Login, marker)
+		fmt.Printf("    %s\n", g.HTMLURL)
+		for filename := range g.Files {
+			expanded, err := g.InstallPath(filename)
+			if err != nil {
+				unsafe++
+				fmt.Printf("      %s ✗\n", filename)
+				continue
+			}
+			if IsScriptFile(expanded) {
+				scripts++
+				fmt.Printf("      %s ⚡\n", expanded)
+			}
+		}
+	}
+
+	if scripts > 0 {
+		fmt.Println()
+		fmt.Printf("  ⚠️  Contains %d script(s) — review before running\n", scripts)
+	}
+	if unsafe > 0 {
+		fmt.Println()
+		fmt.Printf("  ⛔ %d file(s) have unsafe names and cannot be installed\n", unsafe)
+	}
+
+	fmt.Println()
+	fmt.Printf("  [y] Install %d skill(s)    [trust-author] Trust all listed authors\n", len(members))
+	fmt.Println("  [N] Abort")
+	fmt.Print("  > ")
+
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		input, _ := reader.ReadString('\n')
+		input = strings.TrimSpace(strings.ToLower(input))
+
+		switch input {
+		case "y", "yes":
+			return "install", nil
+		case "trust-author", "trust":
+			return "trust-author", nil
+		case "n", "no", "":
+			return "", nil
+		default:
+			fmt.Print("  Invalid choice. [y/trust-author/N] > ")
+		}
+	}
+}
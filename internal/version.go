@@ -0,0 +1,193 @@
+package internal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version as found in a skill's front matter.
+type Version struct {
+	Major, Minor, Patch int
+	Pre                 string // pre-release identifiers, without the leading -
+	prefix              bool   // written with a leading v
+}
+
+var versionPattern = regexp.MustCompile(`^(v)?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// ParseVersion parses a semantic version. A leading v is allowed, missing
+// minor and patch numbers count as 0 and build metadata is ignored.
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return Version{}, fmt.Errorf("%q is not a semantic version", s)
+	}
+	num := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+	return Version{Major: num(m[2]), Minor: num(m[3]), Patch: num(m[4]), Pre: m[5], prefix: m[1] != ""}, nil
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	if v.prefix {
+		s = "v" + s
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 as v is older than, equal to or newer than o,
+// following semver precedence.
+func (v Version) Compare(o Version) int {
+	for _, d := range []int{v.Major - o.Major, v.Minor - o.Minor, v.Patch - o.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+	switch {
+	case v.Pre == o.Pre:
+		return 0
+	case v.Pre == "":
+		return 1 // a release is newer than its pre-releases
+	case o.Pre == "":
+		return -1
+	}
+	a, b := strings.Split(v.Pre, "."), strings.Split(o.Pre, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] == b[i] {
+			continue
+		}
+		na, errA := strconv.Atoi(a[i])
+		nb, errB := strconv.Atoi(b[i])
+		switch {
+		case errA == nil && errB == nil:
+			return sign(na - nb)
+		case errA == nil:
+			return -1 // numeric identifiers sort before alphanumeric ones
+		case errB == nil:
+			return 1
+		}
+		return strings.Compare(a[i], b[i])
+	}
+	return sign(len(a) - len(b))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+// CompareVersions compares two version strings. ok is false if either is
+// not a semantic version.
+func CompareVersions(a, b string) (cmp int, ok bool) {
+	va, err := ParseVersion(a)
+	if err != nil {
+		return 0, false
+	}
+	vb, err := ParseVersion(b)
+	if err != nil {
+		return 0, false
+	}
+	return va.Compare(vb), true
+}
+
+// BumpVersion increments the patch, minor or major part of current. An empty
+// current version starts from 0.0.0. Bumping a pre-release releases it: the
+// pre-release of 1.2.0-rc.1 bumps to 1.2.0 for minor or patch.
+func BumpVersion(current, part string) (string, error) {
+	v := Version{}
+	if strings.TrimSpace(current) != "" {
+		var err error
+		if v, err = ParseVersion(current); err != nil {
+			return "", err
+		}
+	}
+	pre := v.Pre != ""
+	v.Pre = ""
+	switch part {
+	case "major":
+		if !pre || v.Minor != 0 || v.Patch != 0 {
+			v.Major, v.Minor, v.Patch = v.Major+1, 0, 0
+		}
+	case "minor":
+		if !pre || v.Patch != 0 {
+			v.Minor, v.Patch = v.Minor+1, 0
+		}
+	case "patch":
+		if !pre {
+			v.Patch++
+		}
+	default:
+		return "", fmt.Errorf("unknown version part %q (use patch, minor or major)", part)
+	}
+	return v.String(), nil
+}
+
+// SetFrontMatterVersion returns the SKILL.md content with the front matter
+// version set to version, adding the field (or the front matter) if missing.
+func SetFrontMatterVersion(content, version string) string {
+	return SetFrontMatterField(content, "version", version)
+}
+
+// SetFrontMatterField returns the SKILL.md content with the top-level front
+// matter field key set to the scalar value, adding the field (or the front
+// matter) if missing.
+func SetFrontMatterField(content, key, value string) string {
+	line := key + ": " + value
+	field := regexp.MustCompile(`^` + regexp.QuoteMeta(key) + `\s*:`)
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return "---\n" + line + "\n---\n" + content
+	}
+	for i := 1; i < len(lines); i++ {
+		switch {
+		case strings.TrimSpace(lines[i]) == "---":
+			lines = append(lines[:i], append([]string{line}, lines[i:]...)...)
+			return strings.Join(lines, "\n")
+		case field.MatchString(lines[i]):
+			lines[i] = line
+			return strings.Join(lines, "\n")
+		}
+	}
+	return content // unterminated front matter; leave it for the user to fix
+}
+
+// ChangelogFile is the changelog publish --bump keeps in a skill folder.
+const ChangelogFile = "CHANGELOG.md"
+
+// AddChangelogEntry records version in dir's CHANGELOG.md, newest first,
+// creating the file if needed.
+func AddChangelogEntry(dir, version, date, message string) error {
+	path := filepath.Join(dir, ChangelogFile)
+	entry := fmt.Sprintf("## %s - %s\n\n- %s\n", version, date, message)
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	content := string(data)
+	switch {
+	case content == "":
+		content = "# Changelog\n\n" + entry
+	case strings.HasPrefix(content, "## "):
+		content = entry + "\n" + content
+	case strings.Contains(content, "\n## "):
+		i := strings.Index(content, "\n## ")
+		content = content[:i+1] + entry + "\n" + content[i+1:]
+	default:
+		content = strings.TrimRight(content, "\n") + "\n\n" + entry
+	}
+	return os.WriteFile(path, []byte(content), 0644)
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"1.2.3", "1.2.3", 0, true},
+		{"1.0", "1.0.0", 0, true},
+		{"v2.0.0", "1.9.9", 1, true},
+		{"1.10.0", "1.9.0", 1, true},
+		{"1.0.0-rc.1", "1.0.0", -1, true},
+		{"1.0.0-rc.2", "1.0.0-rc.10", -1, true},
+		{"1.0.0-alpha", "1.0.0-1", 1, true},
+		{"1.0.0+build.5", "1.0.0", 0, true},
+		{"latest", "1.0.0", 0, false},
+		{"", "1.0.0", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := CompareVersions(tt.a, tt.b)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CompareVersions(%q, %q) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestBumpVersion(t *testing.T) {
+	tests := []struct {
+		current, part, want string
+	}{
+		{"1.2.3", "patch", "1.2.4"},
+		{"1.2.3", "minor", "1.3.0"},
+		{"1.2.3", "major", "2.0.0"},
+		{"v0.9", "minor", "v0.10.0"},
+		{"", "patch", "0.0.1"},
+		{"1.2.0-rc.1", "minor", "1.2.0"},
+		{"1.2.1-rc.1", "minor", "1.3.0"},
+		{"2.0.0-beta", "major", "2.0.0"},
+	}
+	for _, tt := range tests {
+		got, err := BumpVersion(tt.current, tt.part)
+		if err != nil || got != tt.want {
+			t.Errorf("BumpVersion(%q, %q) = %q, %v, want %q", tt.current, tt.part, got, err, tt.want)
+		}
+	}
+	if _, err := BumpVersion("1.0.0", "huge"); err == nil {
+		t.Error("BumpVersion() with unknown part succeeded")
+	}
+	if _, err := BumpVersion("next", "patch"); err == nil {
+		t.Error("BumpVersion() of a non-semver version succeeded")
+	}
+}
+
+func TestSetFrontMatterVersion(t *testing.T) {
+	tests := []struct {
+		name, content, want string
+	}{
+		{"replace", "---\nname: demo\nversion: 1.0.0\n---\nBody\n", "---\nname: demo\nversion: 1.0.1\n---\nBody\n"},
+		{"add", "---\nname: demo\n---\nBody\n", "---\nname: demo\nversion: 1.0.1\n---\nBody\n"},
+		{"no front matter", "Body\n", "---\nversion: 1.0.1\n---\nBody\n"},
+	}
+	for _, tt := range tests {
+		if got := SetFrontMatterVersion(tt.content, "1.0.1"); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAddChangelogEntry(t *testing.T) {
+	dir := t.TempDir()
+	if err := AddChangelogEntry(dir, "1.0.0", "2026-01-01", "First release"); err != nil {
+		t.Fatalf("AddChangelogEntry() error: %v", err)
+	}
+	if err := AddChangelogEntry(dir, "1.1.0", "2026-02-01", "Add examples"); err != nil {
+		t.Fatalf("AddChangelogEntry() error: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ChangelogFile))
+	want := "# Changelog\n\n## 1.1.0 - 2026-02-01\n\n- Add examples\n\n## 1.0.0 - 2026-01-01\n\n- First release\n"
+	if string(data) != want {
+		t.Errorf("CHANGELOG.md = %q, want %q", data, want)
+	}
+}